@@ -54,7 +54,7 @@ var cacheClearCmd = &cobra.Command{
 			}
 		}
 
-		fmt.Printf("%s Cache cleared!\n", green("✓"))
+		fmt.Printf("%sCache cleared!\n", green(GetTheme().Prefix("ok")))
 		fmt.Printf("  Removed %d files (%s)\n", count, formatSize(size))
 		return nil
 	},
@@ -74,8 +74,7 @@ var cacheShowCmd = &cobra.Command{
 		yellow := color.New(color.FgYellow).SprintFunc()
 
 		fmt.Println()
-		fmt.Printf("📦 %s\n", cyan("Cache Status"))
-		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		printHeading(GetTheme(), "cache", cyan("Cache Status"), 45)
 		fmt.Println()
 
 		// Check if cache directory exists
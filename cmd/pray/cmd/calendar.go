@@ -95,7 +95,7 @@ func runCalendarGet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use spinner for download
-	spinner := ui.NewSpinner("Downloading calendar...")
+	spinner := ui.NewSpinner("Downloading calendar...", GetTheme())
 	spinner.Start()
 
 	// Download
@@ -110,9 +110,9 @@ func runCalendarGet(cmd *cobra.Command, args []string) error {
 	}
 
 	spinner.Stop()
-	fmt.Printf("%s Calendar saved to: %s\n", green("✓"), outputFile)
+	fmt.Printf("%sCalendar saved to: %s\n", green(GetTheme().Prefix("ok")), outputFile)
 	fmt.Println()
-	fmt.Println("📍 Import this file into your calendar app:")
+	fmt.Printf("%sImport this file into your calendar app:\n", GetTheme().Prefix("location"))
 	fmt.Println("   - Google Calendar: Settings > Import & export > Import")
 	fmt.Println("   - Apple Calendar: File > Import")
 	fmt.Println("   - Outlook: File > Open > Import")
@@ -138,8 +138,7 @@ func runCalendarURL(cmd *cobra.Command, args []string) error {
 	icsURL := calendar.GenerateICSURL(params)
 
 	fmt.Println()
-	fmt.Println("📅 Calendar Subscription URL")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	printHeading(GetTheme(), "calendar", "Calendar Subscription URL", 53)
 	fmt.Println()
 	fmt.Println(cyan(icsURL))
 	fmt.Println()
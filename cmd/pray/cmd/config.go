@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -13,7 +15,9 @@ import (
 
 	"github.com/AbdElrahmaN31/pray-cli/internal/config"
 	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
 	"github.com/AbdElrahmaN31/pray-cli/internal/ui"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 var configCmd = &cobra.Command{
@@ -34,8 +38,7 @@ var configShowCmd = &cobra.Command{
 			return fmt.Errorf("failed to marshal config: %w", err)
 		}
 
-		fmt.Println("Current configuration:")
-		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		printHeading(GetTheme(), "", "Current configuration:", 45)
 		fmt.Print(string(data))
 		return nil
 	},
@@ -92,11 +95,11 @@ var configValidateCmd = &cobra.Command{
 		cfg := GetConfig()
 
 		if err := cfg.Validate(); err != nil {
-			fmt.Printf("❌ Configuration is invalid: %v\n", err)
+			fmt.Printf("%sConfiguration is invalid: %v\n", GetTheme().Prefix("error"), err)
 			return err
 		}
 
-		fmt.Println("✅ Configuration is valid")
+		fmt.Printf("%sConfiguration is valid\n", GetTheme().Prefix("ok"))
 		return nil
 	},
 }
@@ -128,7 +131,7 @@ var configResetCmd = &cobra.Command{
 			return fmt.Errorf("failed to write config: %w", err)
 		}
 
-		fmt.Println("✅ Configuration reset to defaults")
+		fmt.Printf("%sConfiguration reset to defaults\n", GetTheme().Prefix("ok"))
 		fmt.Printf("   Saved to: %s\n", path)
 		return nil
 	},
@@ -144,7 +147,7 @@ var configDetectCmd = &cobra.Command{
 		green := color.New(color.FgGreen).SprintFunc()
 
 		// Use spinner for the detection process
-		spinner := ui.NewSpinner("Detecting location from IP...")
+		spinner := ui.NewSpinner("Detecting location from IP...", GetTheme())
 		spinner.Start()
 
 		detector := location.NewDetector()
@@ -158,7 +161,7 @@ var configDetectCmd = &cobra.Command{
 		}
 
 		spinner.Stop()
-		fmt.Printf("%s Detected: %s\n", green("✓"), cyan(loc.GetDisplayAddress()))
+		fmt.Printf("%sDetected: %s\n", green(GetTheme().Prefix("ok")), cyan(loc.GetDisplayAddress()))
 		fmt.Printf("  Coordinates: %.4f°N, %.4f°E\n", loc.Latitude, loc.Longitude)
 		fmt.Printf("  Timezone: %s\n", loc.Timezone)
 		fmt.Println()
@@ -172,7 +175,7 @@ var configDetectCmd = &cobra.Command{
 			}
 
 			path, _ := config.GetConfigPath()
-			fmt.Printf("%s Location saved to: %s\n", green("✓"), path)
+			fmt.Printf("%sLocation saved to: %s\n", green(GetTheme().Prefix("ok")), path)
 		} else {
 			fmt.Println("Use --save flag to save this location to your config.")
 		}
@@ -191,10 +194,36 @@ Available keys:
   latitude        - Latitude in decimal degrees
   longitude       - Longitude in decimal degrees
   method          - Calculation method ID (0-23)
-  language        - Language: en or ar
-  output.format   - Output format: table/pretty/json/slack/discord
+  language        - Language code (see 'pray languages')
+  output.format   - Output format: table/pretty/json/slack/discord/webhook/template/csv/tsv/markdown/html/yaml/xml/ndjson/waybar/i3bar/polybar/tmux
+  output.template - Go template for the template format (e.g., "{{.Next.Name}} in {{.Next.In}}")
+  output.delimiter - CSV field delimiter: a single character (e.g., ";") or "tab"
+  output.date_format - Go layout of dates in csv/tsv output (e.g., "02/01/2006")
+  output.html_theme - Colors of HTML output: light, dark or auto (follow the browser)
+  output.numerals - Numerals: latin or native (e.g., Eastern Arabic digits)
+  output.time_format - Time format: 24h, 12h (AM/PM) or locale
+  output.theme    - Theme: auto, emoji, unicode, ascii or plain (screen readers)
+  output.no_emoji - Never use emoji, even on a terminal: true/false
   features.qibla  - Include Qibla direction: true/false
-  features.hijri  - Hijri date display: title/desc/both/none`,
+  features.hijri  - Hijri date display: title/desc/both/none
+  iqama.enabled   - Show Iqama times: true/false
+  iqama.offsets   - Minutes after Adhan per prayer (e.g., "15,0,10,10,5,10,0")
+  iqama.fixed     - Fixed Iqama times (e.g., "Isha: 20:30, Fajr: 05:00")
+  iqama.round     - Round Iqama times to the nearest N minutes (0 = off)
+  jumuah.enabled  - Replace Dhuhr with Jumu'ah on Fridays: true/false
+  jumuah.times    - Jumu'ah khutbah times (e.g., "13:00,14:15"), empty uses Dhuhr
+  jumuah.duration - Jumu'ah duration in minutes (1-180)
+  home.latitude   - Home latitude for travel detection (default: location)
+  home.longitude  - Home longitude for travel detection (default: location)
+  travel.auto     - Enable traveler mode when --auto detects you far from home: true/false
+  travel.threshold - Distance from home in km that counts as travel (default: 80)
+  countdown.alerts - Countdown alert thresholds before each prayer (e.g., "10m,5m,0")
+  countdown.bell  - Ring the terminal bell at countdown alerts: true/false
+  countdown.command - Command run at countdown alerts (PRAY_PRAYER, PRAY_TIME, PRAY_REMAINING are set)
+  kiosk.announcements - Kiosk announcement lines, separated by ";"
+  kiosk.rotate    - Seconds each kiosk announcement or du'a is shown (0-3600, 0 = default 15)
+  prompt.format   - Template of the pray prompt segment (see pray prompt --help)
+  prompt.timeout  - Milliseconds pray prompt may take before printing nothing (0-5000, 0 = default 100)`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
@@ -228,12 +257,14 @@ Available keys:
 			}
 			cfg.Method = method
 		case "language":
-			if value != "en" && value != "ar" {
-				return fmt.Errorf("language must be 'en' or 'ar'")
+			catalog := output.GetCatalog(value)
+			if catalog == nil {
+				return fmt.Errorf("unknown language: %s (available: %s)", value, strings.Join(output.Languages(), ", "))
 			}
+			value = catalog.Code
 			cfg.Language = value
 		case "output.format":
-			valid := []string{"table", "pretty", "json", "slack", "discord", "webhook"}
+			valid := output.FormatTypes()
 			isValid := false
 			for _, v := range valid {
 				if value == v {
@@ -245,6 +276,40 @@ Available keys:
 				return fmt.Errorf("invalid output format: %s", value)
 			}
 			cfg.Output.Format = value
+		case "output.numerals":
+			if value != "latin" && value != "native" {
+				return fmt.Errorf("numerals must be 'latin' or 'native'")
+			}
+			cfg.Output.Numerals = value
+		case "output.time_format":
+			if !slices.Contains(config.DefaultTimeFormats, value) {
+				return fmt.Errorf("time format must be 24h, 12h, or locale")
+			}
+			cfg.Output.TimeFormat = value
+		case "output.theme":
+			if !slices.Contains(output.ThemeNames(), value) {
+				return fmt.Errorf("theme must be one of: %s", strings.Join(output.ThemeNames(), ", "))
+			}
+			cfg.Output.Theme = value
+		case "output.no_emoji":
+			cfg.Output.NoEmoji = value == "true"
+		case "output.template":
+			cfg.Output.Template = value
+		case "output.delimiter":
+			if _, err := output.ParseDelimiter(value); err != nil {
+				return err
+			}
+			cfg.Output.Delimiter = value
+		case "output.date_format":
+			if value == "" {
+				return fmt.Errorf("date format cannot be empty")
+			}
+			cfg.Output.DateFormat = value
+		case "output.html_theme":
+			if !slices.Contains(output.HTMLThemes, value) {
+				return fmt.Errorf("HTML theme must be one of: %s", strings.Join(output.HTMLThemes, ", "))
+			}
+			cfg.Output.HTMLTheme = value
 		case "features.qibla":
 			cfg.Features.Qibla = value == "true"
 		case "features.dua":
@@ -262,6 +327,108 @@ Available keys:
 				return fmt.Errorf("invalid hijri option: %s", value)
 			}
 			cfg.Features.Hijri = value
+		case "iqama.enabled":
+			cfg.Iqama.Enabled = value == "true"
+		case "iqama.offsets":
+			if _, err := prayer.ParseIqamaOffsets(value); err != nil {
+				return err
+			}
+			cfg.Iqama.Offsets = value
+		case "iqama.fixed":
+			if _, err := prayer.ParseIqamaFixed(value); err != nil {
+				return err
+			}
+			cfg.Iqama.Fixed = value
+		case "iqama.round":
+			var round int
+			if _, err := fmt.Sscanf(value, "%d", &round); err != nil {
+				return fmt.Errorf("invalid rounding: %s", value)
+			}
+			if round < 0 || round > 60 {
+				return fmt.Errorf("iqama.round must be between 0 and 60")
+			}
+			cfg.Iqama.Round = round
+		case "jumuah.enabled":
+			cfg.Jumuah.Enabled = value == "true"
+		case "jumuah.times":
+			if _, err := prayer.ParseJumuahTimes(value); err != nil {
+				return err
+			}
+			cfg.Jumuah.Times = value
+		case "jumuah.duration":
+			var duration int
+			if _, err := fmt.Sscanf(value, "%d", &duration); err != nil {
+				return fmt.Errorf("invalid duration: %s", value)
+			}
+			if duration < 1 || duration > 180 {
+				return fmt.Errorf("jumuah.duration must be between 1 and 180")
+			}
+			cfg.Jumuah.Duration = duration
+		case "home.latitude":
+			var lat float64
+			if _, err := fmt.Sscanf(value, "%f", &lat); err != nil {
+				return fmt.Errorf("invalid latitude: %s", value)
+			}
+			if err := config.ValidateLatitude(lat); err != nil {
+				return err
+			}
+			cfg.Home.Latitude = lat
+		case "home.longitude":
+			var lon float64
+			if _, err := fmt.Sscanf(value, "%f", &lon); err != nil {
+				return fmt.Errorf("invalid longitude: %s", value)
+			}
+			if err := config.ValidateLongitude(lon); err != nil {
+				return err
+			}
+			cfg.Home.Longitude = lon
+		case "travel.auto":
+			cfg.Travel.Auto = value == "true"
+		case "travel.threshold":
+			var threshold float64
+			if _, err := fmt.Sscanf(value, "%f", &threshold); err != nil {
+				return fmt.Errorf("invalid threshold: %s", value)
+			}
+			if threshold < 1 || threshold > 1000 {
+				return fmt.Errorf("travel.threshold must be between 1 and 1000 km")
+			}
+			cfg.Travel.Threshold = threshold
+		case "countdown.alerts":
+			if _, err := prayer.ParseThresholds(value); err != nil {
+				return err
+			}
+			cfg.Countdown.Alerts = value
+		case "countdown.bell":
+			cfg.Countdown.Bell = value == "true"
+		case "countdown.command":
+			cfg.Countdown.Command = value
+		case "kiosk.announcements":
+			cfg.Kiosk.Announcements = nil
+			for _, line := range strings.Split(value, ";") {
+				if line = strings.TrimSpace(line); line != "" {
+					cfg.Kiosk.Announcements = append(cfg.Kiosk.Announcements, line)
+				}
+			}
+		case "kiosk.rotate":
+			var seconds int
+			if _, err := fmt.Sscanf(value, "%d", &seconds); err != nil {
+				return fmt.Errorf("invalid rotate: %s", value)
+			}
+			if seconds < 0 || seconds > 3600 {
+				return fmt.Errorf("kiosk.rotate must be between 0 and 3600 seconds (0 for the default)")
+			}
+			cfg.Kiosk.Rotate = seconds
+		case "prompt.format":
+			cfg.Prompt.Format = value
+		case "prompt.timeout":
+			var ms int
+			if _, err := fmt.Sscanf(value, "%d", &ms); err != nil {
+				return fmt.Errorf("invalid timeout: %s", value)
+			}
+			if ms < 0 || ms > 5000 {
+				return fmt.Errorf("prompt.timeout must be between 0 and 5000 milliseconds (0 for the default)")
+			}
+			cfg.Prompt.Timeout = ms
 		default:
 			return fmt.Errorf("unknown config key: %s", key)
 		}
@@ -270,7 +437,7 @@ Available keys:
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		fmt.Printf("%s Set %s = %s\n", green("✓"), key, value)
+		fmt.Printf("%sSet %s = %s\n", green(GetTheme().Prefix("ok")), key, value)
 		return nil
 	},
 }
@@ -298,12 +465,64 @@ var configGetCmd = &cobra.Command{
 			value = cfg.Language
 		case "output.format":
 			value = cfg.Output.Format
+		case "output.numerals":
+			value = cfg.Output.Numerals
+		case "output.time_format":
+			value = cfg.Output.TimeFormat
+		case "output.theme":
+			value = cfg.Output.Theme
+		case "output.no_emoji":
+			value = cfg.Output.NoEmoji
+		case "output.template":
+			value = cfg.Output.Template
+		case "output.delimiter":
+			value = cfg.Output.Delimiter
+		case "output.date_format":
+			value = cfg.Output.DateFormat
+		case "output.html_theme":
+			value = cfg.Output.HTMLTheme
 		case "features.qibla":
 			value = cfg.Features.Qibla
 		case "features.dua":
 			value = cfg.Features.Dua
 		case "features.hijri":
 			value = cfg.Features.Hijri
+		case "iqama.enabled":
+			value = cfg.Iqama.Enabled
+		case "iqama.offsets":
+			value = cfg.Iqama.Offsets
+		case "iqama.fixed":
+			value = cfg.Iqama.Fixed
+		case "iqama.round":
+			value = cfg.Iqama.Round
+		case "jumuah.enabled":
+			value = cfg.Jumuah.Enabled
+		case "jumuah.times":
+			value = cfg.Jumuah.Times
+		case "jumuah.duration":
+			value = cfg.Jumuah.Duration
+		case "home.latitude":
+			value = cfg.Home.Latitude
+		case "home.longitude":
+			value = cfg.Home.Longitude
+		case "travel.auto":
+			value = cfg.Travel.Auto
+		case "travel.threshold":
+			value = cfg.Travel.Threshold
+		case "countdown.alerts":
+			value = cfg.Countdown.Alerts
+		case "countdown.bell":
+			value = cfg.Countdown.Bell
+		case "countdown.command":
+			value = cfg.Countdown.Command
+		case "kiosk.announcements":
+			value = strings.Join(cfg.Kiosk.Announcements, "; ")
+		case "kiosk.rotate":
+			value = cfg.Kiosk.Rotate
+		case "prompt.format":
+			value = cfg.Prompt.Format
+		case "prompt.timeout":
+			value = cfg.Prompt.Timeout
 		case "timezone":
 			value = cfg.Location.Timezone
 		default:
@@ -322,8 +541,7 @@ var configLocationCmd = &cobra.Command{
 		cfg := GetConfig()
 		loc := cfg.Location
 
-		fmt.Println("📍 Location Information")
-		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		printHeading(GetTheme(), "location", "Location Information", 45)
 		fmt.Printf("  Address:     %s\n", loc.GetDisplayAddress())
 		fmt.Printf("  Latitude:    %.4f\n", loc.Latitude)
 		fmt.Printf("  Longitude:   %.4f\n", loc.Longitude)
@@ -362,78 +580,32 @@ This command will:
 			if err := defaultCfg.Save(); err != nil {
 				return fmt.Errorf("failed to create config: %w", err)
 			}
-			fmt.Printf("%s Created default config at: %s\n", green("✓"), path)
+			fmt.Printf("%sCreated default config at: %s\n", green(GetTheme().Prefix("ok")), path)
 			return nil
 		}
 
 		// Backup current config
-		fmt.Println("📋 Backing up current config...")
+		fmt.Printf("%sBacking up current config...\n", GetTheme().Prefix("backup"))
 		if err := config.Backup(); err != nil {
-			fmt.Printf("%s Could not backup config: %v\n", yellow("⚠"), err)
+			fmt.Printf("%sCould not backup config: %v\n", yellow(GetTheme().Prefix("warn")), err)
 		} else {
-			fmt.Printf("%s Backup created: %s.backup\n", green("✓"), path)
+			fmt.Printf("%sBackup created: %s.backup\n", green(GetTheme().Prefix("ok")), path)
 		}
 
 		// Try to load current config
-		fmt.Println("🔧 Attempting to repair config...")
+		fmt.Printf("%sAttempting to repair config...\n", GetTheme().Prefix("repair"))
 		currentCfg, err := config.Load()
 		if err != nil {
-			fmt.Printf("%s Config is corrupted, resetting to defaults\n", yellow("⚠"))
+			fmt.Printf("%sConfig is corrupted, resetting to defaults\n", yellow(GetTheme().Prefix("warn")))
 			currentCfg = config.DefaultConfig()
 		}
 
 		// Validate and fix issues
-		defaultCfg := config.DefaultConfig()
-		repaired := false
-
-		// Fix method if invalid
-		if !config.ValidMethodID(currentCfg.Method) {
-			fmt.Printf("  Fixed: method %d → %d\n", currentCfg.Method, defaultCfg.Method)
-			currentCfg.Method = defaultCfg.Method
-			repaired = true
-		}
-
-		// Fix language if invalid
-		if currentCfg.Language != "en" && currentCfg.Language != "ar" {
-			fmt.Printf("  Fixed: language '%s' → '%s'\n", currentCfg.Language, defaultCfg.Language)
-			currentCfg.Language = defaultCfg.Language
-			repaired = true
-		}
-
-		// Fix output format if invalid
-		validFormats := []string{"table", "pretty", "json", "slack", "discord", "webhook"}
-		formatValid := false
-		for _, f := range validFormats {
-			if currentCfg.Output.Format == f {
-				formatValid = true
-				break
-			}
-		}
-		if !formatValid {
-			fmt.Printf("  Fixed: output.format '%s' → '%s'\n", currentCfg.Output.Format, defaultCfg.Output.Format)
-			currentCfg.Output.Format = defaultCfg.Output.Format
-			repaired = true
-		}
-
-		// Fix calendar settings
-		if currentCfg.Calendar.Duration < 1 || currentCfg.Calendar.Duration > 120 {
-			fmt.Printf("  Fixed: calendar.duration %d → %d\n", currentCfg.Calendar.Duration, defaultCfg.Calendar.Duration)
-			currentCfg.Calendar.Duration = defaultCfg.Calendar.Duration
-			repaired = true
-		}
-
-		if currentCfg.Calendar.Months < 1 || currentCfg.Calendar.Months > 12 {
-			fmt.Printf("  Fixed: calendar.months %d → %d\n", currentCfg.Calendar.Months, defaultCfg.Calendar.Months)
-			currentCfg.Calendar.Months = defaultCfg.Calendar.Months
-			repaired = true
-		}
-
-		// Fix API timeout
-		if currentCfg.APITimeout < 5 || currentCfg.APITimeout > 120 {
-			fmt.Printf("  Fixed: api_timeout %d → %d\n", currentCfg.APITimeout, defaultCfg.APITimeout)
-			currentCfg.APITimeout = defaultCfg.APITimeout
-			repaired = true
+		fixes := repairConfig(currentCfg)
+		for _, fix := range fixes {
+			fmt.Printf("  Fixed: %s\n", fix)
 		}
+		repaired := len(fixes) > 0
 
 		// Save repaired config
 		if err := currentCfg.Save(); err != nil {
@@ -441,15 +613,153 @@ This command will:
 		}
 
 		if repaired {
-			fmt.Printf("\n%s Configuration repaired and saved!\n", green("✓"))
+			fmt.Printf("\n%sConfiguration repaired and saved!\n", green(GetTheme().Prefix("ok")))
 		} else {
-			fmt.Printf("\n%s Configuration is valid, no repairs needed.\n", green("✓"))
+			fmt.Printf("\n%sConfiguration is valid, no repairs needed.\n", green(GetTheme().Prefix("ok")))
 		}
 
 		return nil
 	},
 }
 
+// repairConfig resets each invalid setting of cfg to its default, dropping invalid mosque
+// profiles, and returns a line for each fix
+func repairConfig(cfg *config.Config) []string {
+	defaultCfg := config.DefaultConfig()
+	var fixes []string
+	fix := func(format string, args ...any) {
+		fixes = append(fixes, fmt.Sprintf(format, args...))
+	}
+
+	// Fix method if invalid
+	if !config.ValidMethodID(cfg.Method) {
+		fix("method %d → %d", cfg.Method, defaultCfg.Method)
+		cfg.Method = defaultCfg.Method
+	}
+
+	// Fix language if invalid, or not written as its catalog's code
+	if catalog := output.GetCatalog(cfg.Language); catalog == nil {
+		fix("language '%s' → '%s'", cfg.Language, defaultCfg.Language)
+		cfg.Language = defaultCfg.Language
+	} else if catalog.Code != cfg.Language {
+		fix("language '%s' → '%s'", cfg.Language, catalog.Code)
+		cfg.Language = catalog.Code
+	}
+
+	// Fix output settings if invalid
+	if !slices.Contains(config.DefaultOutputFormats, cfg.Output.Format) {
+		fix("output.format '%s' → '%s'", cfg.Output.Format, defaultCfg.Output.Format)
+		cfg.Output.Format = defaultCfg.Output.Format
+	}
+	if !slices.Contains(config.DefaultNumerals, cfg.Output.Numerals) {
+		fix("output.numerals '%s' → '%s'", cfg.Output.Numerals, defaultCfg.Output.Numerals)
+		cfg.Output.Numerals = defaultCfg.Output.Numerals
+	}
+	if !slices.Contains(config.DefaultTimeFormats, cfg.Output.TimeFormat) {
+		fix("output.time_format '%s' → '%s'", cfg.Output.TimeFormat, defaultCfg.Output.TimeFormat)
+		cfg.Output.TimeFormat = defaultCfg.Output.TimeFormat
+	}
+	if !slices.Contains(output.ThemeNames(), cfg.Output.Theme) {
+		fix("output.theme '%s' → '%s'", cfg.Output.Theme, defaultCfg.Output.Theme)
+		cfg.Output.Theme = defaultCfg.Output.Theme
+	}
+	if err := config.ValidateDelimiter(cfg.Output.Delimiter); err != nil {
+		fix("output.delimiter '%s' → '%s'", cfg.Output.Delimiter, defaultCfg.Output.Delimiter)
+		cfg.Output.Delimiter = defaultCfg.Output.Delimiter
+	}
+	if !slices.Contains(output.HTMLThemes, cfg.Output.HTMLTheme) {
+		fix("output.html_theme '%s' → '%s'", cfg.Output.HTMLTheme, defaultCfg.Output.HTMLTheme)
+		cfg.Output.HTMLTheme = defaultCfg.Output.HTMLTheme
+	}
+
+	// Fix Hijri display option
+	if !slices.Contains([]string{"title", "desc", "both", "none"}, cfg.Features.Hijri) {
+		fix("features.hijri '%s' → '%s'", cfg.Features.Hijri, defaultCfg.Features.Hijri)
+		cfg.Features.Hijri = defaultCfg.Features.Hijri
+	}
+
+	// Fix calendar settings
+	if cfg.Calendar.Duration < 1 || cfg.Calendar.Duration > 120 {
+		fix("calendar.duration %d → %d", cfg.Calendar.Duration, defaultCfg.Calendar.Duration)
+		cfg.Calendar.Duration = defaultCfg.Calendar.Duration
+	}
+	if cfg.Calendar.Months < 1 || cfg.Calendar.Months > 12 {
+		fix("calendar.months %d → %d", cfg.Calendar.Months, defaultCfg.Calendar.Months)
+		cfg.Calendar.Months = defaultCfg.Calendar.Months
+	}
+
+	// Fix Iqama rules, which are checked together
+	if _, err := prayer.NewIqamaRules(cfg.Iqama.Offsets, cfg.Iqama.Fixed, cfg.Iqama.Round); err != nil {
+		fix("iqama offsets, fixed and round (%v) → defaults", err)
+		cfg.Iqama.Offsets = defaultCfg.Iqama.Offsets
+		cfg.Iqama.Fixed = defaultCfg.Iqama.Fixed
+		cfg.Iqama.Round = defaultCfg.Iqama.Round
+	}
+
+	// Fix Jumu'ah settings
+	if _, err := prayer.ParseJumuahTimes(cfg.Jumuah.Times); err != nil {
+		fix("jumuah.times '%s' → '%s'", cfg.Jumuah.Times, defaultCfg.Jumuah.Times)
+		cfg.Jumuah.Times = defaultCfg.Jumuah.Times
+	}
+	if cfg.Jumuah.Duration < 1 || cfg.Jumuah.Duration > 180 {
+		fix("jumuah.duration %d → %d", cfg.Jumuah.Duration, defaultCfg.Jumuah.Duration)
+		cfg.Jumuah.Duration = defaultCfg.Jumuah.Duration
+	}
+
+	// Fix travel detection settings
+	if cfg.Travel.Threshold < 1 || cfg.Travel.Threshold > 1000 {
+		fix("travel.threshold %g → %g", cfg.Travel.Threshold, defaultCfg.Travel.Threshold)
+		cfg.Travel.Threshold = defaultCfg.Travel.Threshold
+	}
+	if (cfg.Home.Latitude != 0 || cfg.Home.Longitude != 0) && config.ValidateCoordinates(cfg.Home.Latitude, cfg.Home.Longitude) != nil {
+		fix("home %.4f, %.4f → unset", cfg.Home.Latitude, cfg.Home.Longitude)
+		cfg.Home = defaultCfg.Home
+	}
+
+	// Fix countdown, kiosk and prompt settings
+	if _, err := prayer.ParseThresholds(cfg.Countdown.Alerts); err != nil {
+		fix("countdown.alerts '%s' → '%s'", cfg.Countdown.Alerts, defaultCfg.Countdown.Alerts)
+		cfg.Countdown.Alerts = defaultCfg.Countdown.Alerts
+	}
+	if cfg.Kiosk.Rotate < 0 || cfg.Kiosk.Rotate > 3600 {
+		fix("kiosk.rotate %d → %d", cfg.Kiosk.Rotate, defaultCfg.Kiosk.Rotate)
+		cfg.Kiosk.Rotate = defaultCfg.Kiosk.Rotate
+	}
+	if cfg.Prompt.Timeout < 0 || cfg.Prompt.Timeout > 5000 {
+		fix("prompt.timeout %d → %d", cfg.Prompt.Timeout, defaultCfg.Prompt.Timeout)
+		cfg.Prompt.Timeout = defaultCfg.Prompt.Timeout
+	}
+
+	// Drop invalid mosque profiles, which have no defaults
+	mosques := cfg.Mosques[:0]
+	for _, m := range cfg.Mosques {
+		if err := m.Validate(); err != nil {
+			fix("removed mosque '%s' (%v)", m.Name, err)
+			continue
+		}
+		mosques = append(mosques, m)
+	}
+	cfg.Mosques = mosques
+	if cfg.Mosque != "" && cfg.GetMosque(cfg.Mosque) == nil {
+		fix("mosque '%s' → none", cfg.Mosque)
+		cfg.Mosque = ""
+	}
+
+	// Fix API timeout
+	if cfg.APITimeout < 5 || cfg.APITimeout > 120 {
+		fix("api_timeout %d → %d", cfg.APITimeout, defaultCfg.APITimeout)
+		cfg.APITimeout = defaultCfg.APITimeout
+	}
+
+	// Fix location
+	if (cfg.Location.Latitude != 0 || cfg.Location.Longitude != 0) && config.ValidateCoordinates(cfg.Location.Latitude, cfg.Location.Longitude) != nil {
+		fix("location %.4f, %.4f → unset, run 'pray init'", cfg.Location.Latitude, cfg.Location.Longitude)
+		cfg.Location = defaultCfg.Location
+	}
+
+	return fixes
+}
+
 var configExportFile string
 
 var configExportCmd = &cobra.Command{
@@ -477,7 +787,7 @@ If no file is specified, exports to ./pray-config.yaml`,
 			return fmt.Errorf("failed to export config: %w", err)
 		}
 
-		fmt.Printf("%s Configuration exported to: %s\n", green("✓"), outputFile)
+		fmt.Printf("%sConfiguration exported to: %s\n", green(GetTheme().Prefix("ok")), outputFile)
 		return nil
 	},
 }
@@ -502,11 +812,11 @@ This will replace the current configuration with the imported one.`,
 
 		// Backup current config
 		if config.Exists() {
-			fmt.Println("📋 Backing up current config...")
+			fmt.Printf("%sBacking up current config...\n", GetTheme().Prefix("backup"))
 			if err := config.Backup(); err != nil {
-				fmt.Printf("%s Could not backup: %v\n", yellow("⚠"), err)
+				fmt.Printf("%sCould not backup: %v\n", yellow(GetTheme().Prefix("warn")), err)
 			} else {
-				fmt.Printf("%s Backup created\n", green("✓"))
+				fmt.Printf("%sBackup created\n", green(GetTheme().Prefix("ok")))
 			}
 		}
 
@@ -527,7 +837,7 @@ This will replace the current configuration with the imported one.`,
 		}
 
 		path, _ := config.GetConfigPath()
-		fmt.Printf("%s Configuration imported from: %s\n", green("✓"), inputFile)
+		fmt.Printf("%sConfiguration imported from: %s\n", green(GetTheme().Prefix("ok")), inputFile)
 		fmt.Printf("   Saved to: %s\n", path)
 		return nil
 	},
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/config"
+)
+
+func TestConfigRepair(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home+"/.config")
+	t.Setenv("XDG_CACHE_HOME", home+"/.cache")
+	t.Cleanup(func() { rootCmd.SetArgs(nil) })
+
+	// Every setting added since the config was introduced, each invalid
+	invalid := `
+location:
+  latitude: 30.0444
+  longitude: 31.2357
+method: 5
+language: AR
+output:
+  format: table
+  numerals: latin
+  time_format: 24h
+  theme: auto
+  delimiter: ";;"
+  html_theme: sepia
+features:
+  hijri: desc
+calendar:
+  duration: 25
+  months: 3
+iqama:
+  offsets: "15,soon"
+  round: -5
+jumuah:
+  duration: 500
+  times: "25:00"
+travel:
+  threshold: 0
+home:
+  latitude: 200
+  longitude: 31
+countdown:
+  alerts: "10m,later"
+kiosk:
+  rotate: -1
+prompt:
+  timeout: 99999
+mosques:
+  - name: Masjid Noor
+    location:
+      latitude: 30.0444
+      longitude: 31.2357
+    jumuah: ["13:00"]
+  - name: Broken
+    location:
+      latitude: 30.0444
+      longitude: 31.2357
+    jumuah: ["noon"]
+mosque: Broken
+api_timeout: 30
+`
+	path, err := config.GetConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(invalid), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	before, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.Validate() == nil {
+		t.Fatal("the test config should be invalid")
+	}
+
+	rootCmd.SetArgs([]string{"config", "repair"})
+	var runErr error
+	out := captureStdout(t, func() { runErr = rootCmd.Execute() })
+	if runErr != nil {
+		t.Fatalf("pray config repair error = %v", runErr)
+	}
+
+	repaired, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repaired.Validate(); err != nil {
+		t.Errorf("repaired config is invalid: %v\n%s", err, out)
+	}
+
+	// Valid settings are kept
+	if repaired.Language != "ar" || repaired.Location.Latitude != 30.0444 || len(repaired.Mosques) != 1 || repaired.Mosques[0].Name != "Masjid Noor" {
+		t.Errorf("repair should keep valid settings, got language %q, location %v, mosques %v", repaired.Language, repaired.Location, repaired.Mosques)
+	}
+	for _, field := range []string{"output.delimiter", "output.html_theme", "iqama", "jumuah.times", "jumuah.duration", "travel.threshold", "home", "countdown.alerts", "kiosk.rotate", "prompt.timeout", "removed mosque 'Broken'", "mosque 'Broken'"} {
+		if !strings.Contains(out, "Fixed: "+field) {
+			t.Errorf("pray config repair output does not report %s:\n%s", field, out)
+		}
+	}
+}
@@ -1,19 +1,28 @@
 package cmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"math"
 	"os"
+	"os/exec"
 	"os/signal"
+	"runtime"
 	"syscall"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
-	"github.com/AbdElrahmaN31/pray-cli/internal/api"
-	"github.com/AbdElrahmaN31/pray-cli/internal/config"
-	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+var (
+	countdownCompact bool
+	countdownBell    bool
+	countdownExec    string
+	countdownAlerts  string
 )
 
 var countdownCmd = &cobra.Command{
@@ -22,118 +31,93 @@ var countdownCmd = &cobra.Command{
 	Long: `Display a live countdown to the next prayer time.
 
 The countdown updates every second and shows:
-  - Next prayer name and time
+  - Next prayer name and time, rolling over to tomorrow's Fajr after Isha
   - Time remaining (hours, minutes, seconds)
+  - Progress through the current prayer window
   - Current local time
 
+Prayer times are fetched again at midnight. With --bell or --exec, the countdown
+alerts at each threshold before a prayer (countdown.alerts, default "10m,5m,0").
+The command gets PRAY_PRAYER, PRAY_TIME and PRAY_REMAINING (minutes) in its environment.
+
+Use --compact for a single updating line, e.g. in a small tmux pane.
+
+With -o ndjson, the countdown is written as a line of JSON every second ("tick")
+and at each alert threshold ("alert"), for other programs to read:
+
+  pray countdown -o ndjson | jq -c 'select(.data.event == "alert")'
+
 Press Ctrl+C to exit.`,
 	RunE: runCountdownCommand,
 }
 
 func init() {
 	rootCmd.AddCommand(countdownCmd)
+
+	countdownCmd.Flags().BoolVar(&countdownCompact, "compact", false, "show a single updating line")
+	countdownCmd.Flags().BoolVar(&countdownBell, "bell", false, "ring the terminal bell at alert thresholds")
+	countdownCmd.Flags().StringVar(&countdownExec, "exec", "", "command to run at alert thresholds")
+	countdownCmd.Flags().StringVar(&countdownAlerts, "alerts", "", "alert thresholds before each prayer (e.g., '10m,5m,0')")
+}
+
+// countdown is the state of a running countdown
+type countdown struct {
+	source    *prayerSource
+	day       *prayer.Day
+	stale     time.Time        // When the day is estimated, the time to retry fetching it
+	fetched   chan *prayer.Day // Days fetched in the background, nil when a fetch failed
+	fetching  bool             // Whether a background fetch is running
+	requested time.Time        // Date of the last following day fetched, to fetch it once
+
+	thresholds []time.Duration
+	bell       bool
+	command    string
+	stream     bool          // Write ndjson lines instead of drawing the countdown
+	alertEvent *prayer.Event // Event the last reading was taken for
+	remaining  time.Duration // Time remaining at the last reading
 }
 
 func runCountdownCommand(cmd *cobra.Command, args []string) error {
 	cfg := GetConfig()
 
-	// Determine location
-	var lat, lon float64
-	var locationStr string
-	var tz string
-
-	if autoDetect {
-		detector := location.NewDetector()
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel()
-
-		loc, err := detector.DetectFromIP(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to auto-detect location: %w", err)
-		}
-		lat = loc.Latitude
-		lon = loc.Longitude
-		locationStr = loc.GetDisplayAddress()
-		tz = loc.Timezone
-	} else if address != "" {
-		locationStr = address
-	} else if latitude != 0 || longitude != 0 {
-		lat = latitude
-		lon = longitude
-		locationStr = fmt.Sprintf("%.4f, %.4f", lat, lon)
-	} else if cfg.IsConfigured() {
-		lat = cfg.Location.Latitude
-		lon = cfg.Location.Longitude
-		locationStr = cfg.Location.GetDisplayAddress()
-		tz = cfg.Location.Timezone
-	} else {
-		fmt.Println("👋 No location configured. Run 'pray init' or 'pray config detect --save'")
+	source, err := resolveSource()
+	if errors.Is(err, errNoLocation) {
+		fmt.Printf("%sNo location configured. Run 'pray init' or 'pray config detect --save'\n", GetTheme().Prefix("wave"))
 		return nil
 	}
-
-	// Determine method
-	methodID := cfg.Method
-	if method != 0 {
-		methodID = method
+	if err != nil {
+		return err
 	}
+	source.useCache()
 
-	// Fetch prayer times
-	client := api.NewClient(api.WithTimeout(time.Duration(cfg.APITimeout) * time.Second))
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.APITimeout)*time.Second)
-	defer cancel()
-
-	params := api.NewPrayerTimesParams().
-		WithDate(time.Now()).
-		WithMethod(methodID)
-
-	var resp *api.PrayerTimesResponse
-	var err error
-
-	if address != "" {
-		params.WithAddress(address)
-		resp, err = client.GetPrayerTimesByAddress(ctx, params)
-	} else {
-		params.WithCoordinates(lat, lon)
-		if tz != "" {
-			params.WithTimezone(tz)
-		}
-		resp, err = client.GetPrayerTimes(ctx, params)
+	// Alerts: flags > config
+	alerts := cfg.Countdown.Alerts
+	if countdownAlerts != "" {
+		alerts = countdownAlerts
 	}
-
+	thresholds, err := prayer.ParseThresholds(alerts)
 	if err != nil {
-		return fmt.Errorf("failed to fetch prayer times: %w", err)
+		return err
 	}
-
-	// Load timezone
-	var loc *time.Location
-	if tz != "" {
-		loc, err = time.LoadLocation(tz)
-		if err != nil {
-			loc = time.Local
-		}
-	} else if resp.Data.Meta.Timezone != "" {
-		loc, err = time.LoadLocation(resp.Data.Meta.Timezone)
-		if err != nil {
-			loc = time.Local
-		}
-	} else {
-		loc = time.Local
+	command := cfg.Countdown.Command
+	if countdownExec != "" {
+		command = countdownExec
 	}
 
-	// Parse prayer times
-	timings := resp.Data.Timings
-	prayers := []struct {
-		name  string
-		time  string
-		emoji string
-	}{
-		{"Fajr", cleanTime(timings.Fajr), "🌅"},
-		{"Sunrise", cleanTime(timings.Sunrise), "🌄"},
-		{"Dhuhr", cleanTime(timings.Dhuhr), "☀️"},
-		{"Asr", cleanTime(timings.Asr), "🌤️"},
-		{"Maghrib", cleanTime(timings.Maghrib), "🌆"},
-		{"Isha", cleanTime(timings.Isha), "🌙"},
+	day, err := source.fetchDay(time.Now())
+	if err != nil {
+		return err
 	}
+	c := &countdown{
+		source:     source,
+		day:        day,
+		thresholds: thresholds,
+		bell:       countdownBell || cfg.Countdown.Bell,
+		command:    command,
+		stream:     GetOutputFormat() == "ndjson",
+		fetched:    make(chan *prayer.Day, 1),
+	}
+	fridayEnabled := isFridayEnabled(source.mosque)
 
 	// Colors
 	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
@@ -145,6 +129,10 @@ func runCountdownCommand(cmd *cobra.Command, args []string) error {
 		color.NoColor = true
 	}
 
+	l := newLocalizer()
+	th := GetTheme()
+	rule := th.Rule(44)
+
 	// Set up signal handling for clean exit
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -153,42 +141,88 @@ func runCountdownCommand(cmd *cobra.Command, args []string) error {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	// Clear screen and hide cursor
-	fmt.Print("\033[2J\033[H\033[?25l")
-	defer fmt.Print("\033[?25h") // Show cursor on exit
+	// The plain theme prints a line each minute instead of redrawing the screen,
+	// so screen readers are not flooded with updates
+	var lastAnnounced string
+	if !th.Plain && !c.stream {
+		if !countdownCompact {
+			// Clear screen
+			fmt.Print("\033[2J\033[H")
+		}
+		// Hide cursor
+		fmt.Print("\033[?25l")
+		defer fmt.Print("\033[?25h") // Show cursor on exit
+	}
 
 	for {
 		select {
 		case <-sigChan:
-			fmt.Print("\033[?25h") // Show cursor
-			fmt.Println("\n\n👋 Goodbye!")
+			if c.stream {
+				return nil
+			}
+			if countdownCompact && !th.Plain {
+				fmt.Print("\033[?25h\n") // Show cursor
+			} else if !th.Plain {
+				fmt.Print("\033[?25h") // Show cursor
+				fmt.Print("\n\n")
+			}
+			fmt.Printf("%s%s\n", th.Prefix("wave"), l.T("label.goodbye"))
 			return nil
 
+		case day := <-c.fetched:
+			c.receive(day)
+
 		case <-ticker.C:
-			now := time.Now().In(loc)
-
-			// Find next prayer
-			var nextPrayer *struct {
-				name       string
-				time       string
-				emoji      string
-				prayerTime time.Time
+			now := c.refresh()
+			day := c.day
+			nextPrayer := c.next(now)
+			tomorrow := nextPrayer != nil && day.IsLaterDate(nextPrayer.Time)
+			if err := c.checkAlerts(nextPrayer, now); err != nil {
+				return err
 			}
 
-			for _, p := range prayers {
-				prayerTime, err := parseTimeForToday(p.time, now)
-				if err != nil {
-					continue
+			// Progress through the current prayer window
+			var progress float64
+			var windowStart *prayer.Event
+			if nextPrayer != nil {
+				if windowStart, _ = day.Window(now); windowStart != nil {
+					progress = prayer.Progress(windowStart.Time, nextPrayer.Time, now)
+				}
+			}
+
+			if c.stream {
+				if err := printJSON(c.ndjson("tick", nextPrayer, now)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if th.Plain {
+				announcement := l.T("next.none")
+				if nextPrayer != nil {
+					mins := int(nextPrayer.Time.Sub(now).Minutes())
+					key := "countdown.announce"
+					if tomorrow {
+						key = "countdown.announceTomorrow"
+					}
+					announcement = l.T(key, l.Prayer(nextPrayer.Name), l.Duration(mins), l.Time(nextPrayer.Time))
+				}
+				if announcement != lastAnnounced {
+					fmt.Println(announcement)
+					lastAnnounced = announcement
 				}
-				if now.Before(prayerTime) {
-					nextPrayer = &struct {
-						name       string
-						time       string
-						emoji      string
-						prayerTime time.Time
-					}{p.name, p.time, p.emoji, prayerTime}
-					break
+				continue
+			}
+
+			if countdownCompact {
+				line := l.T("next.none")
+				if nextPrayer != nil {
+					line = fmt.Sprintf("%s%s %s %s %s %s%%", prayerPrefix(th, nextPrayer.Name), l.Prayer(nextPrayer.Name), l.Time(nextPrayer.Time),
+						l.T("next.until", yellow(l.Digits(formatCountdown(nextPrayer.Time.Sub(now))))), dim(th.ProgressBar(progress, 10)), l.Digits(fmt.Sprint(int(progress*100))))
 				}
+				// Overwrite the line and clear what is left of the previous one
+				fmt.Printf("\r%s\033[K", line)
+				continue
 			}
 
 			// Clear screen and move cursor to top
@@ -196,33 +230,257 @@ func runCountdownCommand(cmd *cobra.Command, args []string) error {
 
 			// Header
 			fmt.Println()
-			fmt.Printf("  %s %s\n", "⏱️", cyan("Prayer Time Countdown"))
-			fmt.Println("  ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+			fmt.Printf("  %s%s\n", th.Prefix("timer"), cyan(l.T("countdown.title")))
+			fmt.Printf("  %s\n", rule)
 			fmt.Println()
 
 			if nextPrayer == nil {
-				fmt.Printf("  %s\n", yellow("🌙 All prayers for today have passed"))
-				fmt.Printf("  %s\n", dim("Tomorrow's Fajr: "+cleanTime(timings.Fajr)))
+				fmt.Printf("  %s\n", yellow(th.Prefix("night")+l.T("next.none")))
 			} else {
-				remaining := time.Until(nextPrayer.prayerTime)
+				remaining := nextPrayer.Time.Sub(now)
 				hours := int(remaining.Hours())
 				minutes := int(remaining.Minutes()) % 60
 				seconds := int(remaining.Seconds()) % 60
 
-				fmt.Printf("  %s %s\n", nextPrayer.emoji, cyan(fmt.Sprintf("Next Prayer: %s", nextPrayer.name)))
-				fmt.Printf("  %s\n", green(fmt.Sprintf("Time: %s", nextPrayer.time)))
+				when := l.Time(nextPrayer.Time)
+				if tomorrow {
+					when += " " + l.T("next.tomorrow")
+				}
+				fmt.Printf("  %s%s\n", prayerPrefix(th, nextPrayer.Name), cyan(l.T("next.title", l.Prayer(nextPrayer.Name))))
+				fmt.Printf("  %s\n", green(l.T("next.time", when)))
+				fmt.Println()
+				fmt.Printf("  %s\n", yellow(l.Digits(fmt.Sprintf("    %02d : %02d : %02d", hours, minutes, seconds))))
+				fmt.Printf("  %s\n", dim("    "+l.T("countdown.units")))
+
+				if windowStart != nil {
+					fmt.Println()
+					fmt.Printf("  %s %s%%\n", th.ProgressBar(progress, 36), l.Digits(fmt.Sprintf("%3d", int(progress*100))))
+					fmt.Printf("  %s\n", dim(l.T("countdown.window", l.Prayer(windowStart.Name), l.Time(windowStart.Time), l.Prayer(nextPrayer.Name), l.Time(nextPrayer.Time))))
+				}
+
+				if nextPrayer.HasIqama() {
+					fmt.Println()
+					fmt.Printf("  %s\n", green(l.T("next.iqama", l.Time(nextPrayer.Iqama))+" "+l.T("next.iqamaIn", l.Digits(formatCountdown(nextPrayer.Iqama.Sub(now))))))
+				}
+			}
+
+			if w, end, ok := day.ActiveWindow(now); ok && day.Traveler {
 				fmt.Println()
-				fmt.Printf("  %s\n", yellow(fmt.Sprintf("    %02d : %02d : %02d", hours, minutes, seconds)))
-				fmt.Printf("  %s\n", dim("    hr   min   sec"))
+				fmt.Printf("  %s%s\n", th.Prefix("traveler"), yellow(l.T("countdown.windowCloses", l.Prayer(w.Name), l.Digits(formatCountdown(end.Sub(now))), l.Clock(w.End))))
+			}
+
+			if pending := day.PendingIqama(now); pending != nil {
+				fmt.Println()
+				fmt.Printf("  %s%s\n", th.Prefix("mosque"), yellow(l.T("countdown.pendingIqama", l.Prayer(pending.Name), l.Digits(formatCountdown(pending.Iqama.Sub(now))))))
+			}
+
+			if fridayEnabled {
+				if reminders := buildFridayReminders(day, now); len(reminders) > 0 {
+					fmt.Println()
+					for _, r := range reminders {
+						fmt.Printf("  %s%s\n", th.Prefix("reminder"), dim(r))
+					}
+				}
 			}
 
 			fmt.Println()
-			fmt.Println("  ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-			fmt.Printf("  %s %s\n", "📍", dim(locationStr))
-			fmt.Printf("  %s %s\n", "⚙️", dim(config.GetMethodName(methodID)))
-			fmt.Printf("  %s %s\n", "🕐", dim(now.Format("15:04:05")))
+			fmt.Printf("  %s\n", rule)
+			fmt.Printf("  %s%s\n", th.Prefix("location"), dim(source.location))
+			if source.travelNotice != "" {
+				fmt.Printf("  %s%s\n", th.Prefix("traveler"), dim(source.travelNotice))
+			}
+			fmt.Printf("  %s%s\n", th.Prefix("method"), dim(day.Method))
+			if !c.stale.IsZero() {
+				fmt.Printf("  %s%s\n", th.Prefix("warn"), dim(l.T("countdown.offline")))
+			}
+			fmt.Printf("  %s%s\n", th.Prefix("clock"), dim(l.Digits(now.Format("15:04:05"))))
 			fmt.Println()
-			fmt.Printf("  %s\n", dim("Press Ctrl+C to exit"))
+			fmt.Printf("  %s\n", dim(l.T("countdown.exit")))
+		}
+	}
+}
+
+// refresh returns the current time in the day's timezone, first moving to the following
+// day once the date changes. When the day's times are estimated, they are fetched in the
+// background, retrying every few minutes, so the countdown never waits on the network.
+func (c *countdown) refresh() time.Time {
+	now := time.Now().In(c.day.Date.Location())
+	if c.day.IsLaterDate(now) {
+		if c.day.Following == nil {
+			c.stale = now
+		} else {
+			c.stale = time.Time{}
 		}
+		c.day = c.day.Tomorrow()
+		now = now.In(c.day.Date.Location())
+	}
+	if !c.stale.IsZero() && !now.Before(c.stale) && c.fetch(c.day.Date) {
+		c.stale = now.Add(5 * time.Minute)
+	}
+	return now
+}
+
+// next returns the next prayer, which is tomorrow's Fajr after Isha. Tomorrow's times
+// are estimated until they have been fetched in the background.
+// Traveler mode combines prayers into a single event at the time of the first prayer.
+func (c *countdown) next(now time.Time) *prayer.Event {
+	next := c.day.NextPrayer(now)
+	if next != nil && !c.day.IsLaterDate(next.Time) {
+		return next
+	}
+	if tomorrow := c.day.Date.AddDate(0, 0, 1); c.day.Following == nil && !c.requested.Equal(tomorrow) && c.fetch(tomorrow) {
+		c.requested = tomorrow
+	}
+	return next
+}
+
+// fetch fetches the prayer day of date in the background through the response cache and
+// sends it to c.fetched. It reports false when a fetch is already running.
+func (c *countdown) fetch(date time.Time) bool {
+	if c.fetching {
+		return false
+	}
+	c.fetching = true
+	go func() {
+		day, err := c.source.fetchDay(date)
+		if err != nil {
+			day = nil
+		}
+		c.fetched <- day
+	}()
+	return true
+}
+
+// receive takes a day fetched in the background: today's times replace the estimate,
+// and the following day's times replace tomorrow's estimate
+func (c *countdown) receive(day *prayer.Day) {
+	c.fetching = false
+	if day == nil {
+		return
+	}
+	switch day.Date.Format("2006-01-02") {
+	case c.day.Date.Format("2006-01-02"):
+		day.Following = c.day.Following
+		c.day = day
+		c.stale = time.Time{}
+	case c.day.Date.AddDate(0, 0, 1).Format("2006-01-02"):
+		c.day.Following = day
+	}
+}
+
+// checkAlerts rings the bell, runs the alert command and writes an alert line when the
+// time remaining until a prayer passes a threshold. A prayer that was just reached is
+// checked one last time, so a threshold of 0 fires as the next prayer moves on.
+func (c *countdown) checkAlerts(next *prayer.Event, now time.Time) error {
+	if !c.bell && c.command == "" && !c.stream {
+		return nil
+	}
+
+	if c.alertEvent != nil {
+		if threshold, ok := prayer.Crossed(c.thresholds, c.remaining, c.alertEvent.Time.Sub(now)); ok {
+			c.alert(*c.alertEvent, threshold)
+			if c.stream {
+				line := c.ndjson("alert", c.alertEvent, now)
+				minutes := int(threshold.Minutes())
+				line.AlertMinutes = &minutes
+				if err := printJSON(line); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	c.alertEvent = nil
+	if next != nil {
+		event := *next
+		c.alertEvent = &event
+		c.remaining = next.Time.Sub(now)
+	}
+	return nil
+}
+
+// ndjson returns the state of the countdown as a line of ndjson output
+func (c *countdown) ndjson(event string, next *prayer.Event, now time.Time) *output.CountdownOutput {
+	out := &output.CountdownOutput{
+		Event:     event,
+		Now:       now.Format(time.RFC3339),
+		Estimated: !c.stale.IsZero(),
+		Location:  c.source.location,
+	}
+	if next != nil {
+		remaining := max(next.Time.Sub(now), 0)
+		out.Next = &output.NextPrayerOutput{
+			Name:         next.Name,
+			Time:         next.Clock(),
+			Date:         next.Time.Format("2006-01-02"),
+			Tomorrow:     c.day.IsLaterDate(next.Time),
+			MinutesUntil: int(remaining.Minutes()),
+		}
+		if next.HasIqama() {
+			out.Next.Iqama = next.Iqama.Format("15:04")
+		}
+		out.SecondsUntil = int(remaining.Seconds())
+		if start, _ := c.day.Window(now); start != nil {
+			out.Progress = math.Round(prayer.Progress(start.Time, next.Time, now)*1000) / 1000
+		}
+	}
+	if pending := c.day.PendingIqama(now); pending != nil {
+		out.PendingIqama = &output.PendingIqamaOutput{
+			Name:         pending.Name,
+			Time:         pending.Iqama.Format("15:04"),
+			MinutesUntil: int(pending.Iqama.Sub(now).Minutes()),
+		}
+	}
+	if w, end, ok := c.day.ActiveWindow(now); ok && c.day.Traveler {
+		out.CombinedWindow = &output.WindowOutput{
+			Name:         w.Name,
+			End:          w.End,
+			MinutesUntil: int(end.Sub(now).Minutes()),
+		}
+	}
+	return out
+}
+
+// alert rings the terminal bell and starts the alert command for a prayer. The command
+// is not waited for, so a slow command does not stall the countdown.
+func (c *countdown) alert(e prayer.Event, threshold time.Duration) {
+	if c.bell && !c.stream {
+		fmt.Print("\a")
+	}
+	if c.command == "" {
+		return
+	}
+
+	var alertCmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		alertCmd = exec.Command("cmd", "/C", c.command)
+	} else {
+		alertCmd = exec.Command("sh", "-c", c.command)
+	}
+	alertCmd.Env = append(os.Environ(),
+		"PRAY_PRAYER="+e.Name,
+		"PRAY_TIME="+e.Clock(),
+		fmt.Sprintf("PRAY_REMAINING=%d", int(threshold.Minutes())),
+	)
+	if err := alertCmd.Start(); err != nil {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "\nfailed to run alert command: %v\n", err)
+		}
+		return
+	}
+	go alertCmd.Wait()
+}
+
+// formatCountdown formats a duration as HH:MM:SS, or MM:SS when under an hour
+func formatCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
 	}
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
 }
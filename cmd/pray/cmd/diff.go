@@ -12,6 +12,7 @@ import (
 
 	"github.com/AbdElrahmaN31/pray-cli/internal/api"
 	"github.com/AbdElrahmaN31/pray-cli/internal/config"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
 )
 
 var diffCmd = &cobra.Command{
@@ -103,30 +104,32 @@ func runDiffCommand(cmd *cobra.Command, args []string) error {
 		color.NoColor = true
 	}
 
+	th := GetTheme()
+
 	// Header
 	fmt.Println()
-	fmt.Printf("📊 %s\n", cyan("Prayer Times Comparison"))
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("📅 %s\n", resp1.Data.Date.Readable)
+	printHeading(th, "compare", cyan("Prayer Times Comparison"), 64)
+	fmt.Printf("%s%s\n", th.Prefix("calendar"), resp1.Data.Date.Readable)
 	fmt.Println()
 
 	// Create comparison table
-	table := tablewriter.NewTable(os.Stdout)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithSymbols(th.Symbols()))
 	table.Header("Prayer", location1, location2, "Difference")
 
-	// Prayer times to compare
-	prayers := []struct {
+	// Prayer times to compare, as normalized days in each location's timezone
+	day1 := output.NewDay(resp1)
+	day2 := output.NewDay(resp2)
+
+	type comparison struct {
 		name  string
 		time1 string
 		time2 string
-	}{
-		{"Fajr", cleanTime(resp1.Data.Timings.Fajr), cleanTime(resp2.Data.Timings.Fajr)},
-		{"Sunrise", cleanTime(resp1.Data.Timings.Sunrise), cleanTime(resp2.Data.Timings.Sunrise)},
-		{"Dhuhr", cleanTime(resp1.Data.Timings.Dhuhr), cleanTime(resp2.Data.Timings.Dhuhr)},
-		{"Asr", cleanTime(resp1.Data.Timings.Asr), cleanTime(resp2.Data.Timings.Asr)},
-		{"Maghrib", cleanTime(resp1.Data.Timings.Maghrib), cleanTime(resp2.Data.Timings.Maghrib)},
-		{"Isha", cleanTime(resp1.Data.Timings.Isha), cleanTime(resp2.Data.Timings.Isha)},
-		{"Midnight", cleanTime(resp1.Data.Timings.Midnight), cleanTime(resp2.Data.Timings.Midnight)},
+	}
+	var prayers []comparison
+	for _, e := range day1.Events {
+		if other := day2.Event(e.Name); other != nil {
+			prayers = append(prayers, comparison{e.Name, e.Clock(), other.Clock()})
+		}
 	}
 
 	for _, p := range prayers {
@@ -149,7 +152,7 @@ func runDiffCommand(cmd *cobra.Command, args []string) error {
 	table.Render()
 
 	fmt.Println()
-	fmt.Printf("⚙️  Method: %s\n", config.GetMethodName(methodID))
+	fmt.Printf("%sMethod: %s\n", th.Prefix("method"), config.GetMethodName(methodID))
 	fmt.Println()
 	fmt.Println("Note: Positive difference means location 2 is later")
 	fmt.Println()
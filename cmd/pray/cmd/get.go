@@ -1,14 +1,25 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
 )
 
-var dateFlag string
+// maxRangeDays is the largest number of days pray get --days fetches
+const maxRangeDays = 366
+
+var (
+	dateFlag string
+	getDays  int
+)
 
 var getCmd = &cobra.Command{
 	Use:   "get",
@@ -21,17 +32,25 @@ Supported date formats:
   - Weekday: "monday", "tuesday", "friday", etc.
   - Offset: "+1", "+7", "-3" (days from today)
 
+With --days, prayer times are fetched for that many days starting at --date and
+written as a timetable, one row per day. This needs a format that can show several
+days: csv, tsv, markdown, html, or ndjson for a line of JSON per day.
+
 Examples:
   pray get --date tomorrow
   pray get --date 2026-02-15
   pray get --date friday
-  pray get --date +7`,
+  pray get --date +7
+  pray get --date 2026-03-01 --days 31 -o csv -f march.csv
+  pray get --date monday --days 7 -o html -f week.html
+  pray get --days 30 -o ndjson | jq -c '.data.timings'`,
 	RunE: runGetCommand,
 }
 
 func init() {
 	rootCmd.AddCommand(getCmd)
 	getCmd.Flags().StringVar(&dateFlag, "date", "today", "date to fetch prayer times for")
+	getCmd.Flags().IntVar(&getDays, "days", 1, "number of days to fetch from --date, for timetable formats (csv, tsv, markdown, html, ndjson)")
 }
 
 func runGetCommand(cmd *cobra.Command, args []string) error {
@@ -41,9 +60,69 @@ func runGetCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid date format: %w", err)
 	}
 
+	if getDays < 1 || getDays > maxRangeDays {
+		return fmt.Errorf("--days must be between 1 and %d", maxRangeDays)
+	}
+	if format := GetOutputFormat(); output.IsStatusFormat(format) {
+		return fmt.Errorf("the %s format shows the next prayer, use it with 'pray' or 'pray today'", format)
+	}
+	if getDays > 1 {
+		return displayPrayerRange(targetDate, getDays)
+	}
 	return fetchAndDisplayPrayerTimes(cmd, targetDate)
 }
 
+// displayPrayerRange fetches the prayer times of the given number of days from start
+// and writes them with a formatter that can show several days
+func displayPrayerRange(start time.Time, days int) error {
+	format := GetOutputFormat()
+	formatter, ok := output.GetFormatter(format).(output.RangeFormatter)
+	if !ok {
+		return fmt.Errorf("the %s format shows a single day, use -o csv, tsv, markdown, html or ndjson with --days", format)
+	}
+
+	source, err := resolveSource()
+	if errors.Is(err, errNoLocation) {
+		fmt.Printf("%sNo location configured. Run 'pray init' or 'pray config detect --save'\n", GetTheme().Prefix("wave"))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if source.travelNotice != "" && !IsQuiet() {
+		fmt.Fprintf(os.Stderr, "%s%s\n", GetTheme().Prefix("traveler"), source.travelNotice)
+	}
+	source.useCache()
+
+	data := make([]*output.PrayerData, 0, days)
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i)
+		resp, err := source.fetchResponse(date)
+		if err != nil {
+			return err
+		}
+		data = append(data, source.prayerData(resp, nil, date))
+	}
+
+	var w io.Writer = os.Stdout
+	outFile := GetOutputFile()
+	if outFile != "" {
+		f, err := os.Create(outFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := formatter.FormatRange(w, data); err != nil {
+		return err
+	}
+	if outFile != "" && !IsQuiet() {
+		fmt.Printf("%sOutput saved to: %s\n", GetTheme().Prefix("ok"), outFile)
+	}
+	return nil
+}
+
 // parseDate parses various date formats into time.Time
 func parseDate(dateStr string) (time.Time, error) {
 	now := time.Now()
@@ -26,7 +26,7 @@ This will guide you through:
 		cyan := color.New(color.FgCyan).SprintFunc()
 
 		// Run the wizard
-		wizard := ui.NewWizard()
+		wizard := ui.NewWizard(GetTheme())
 		newCfg, err := wizard.Run()
 		if err != nil {
 			return fmt.Errorf("setup wizard failed: %w", err)
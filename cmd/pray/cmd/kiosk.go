@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+var (
+	kioskAnnouncements []string
+	kioskRotate        time.Duration
+)
+
+var kioskCmd = &cobra.Command{
+	Use:   "kiosk",
+	Short: "Full-screen prayer times display for wall screens",
+	Long: `Show a full-screen display for a mosque or office wall screen with:
+  - The current time in large block digits
+  - Today's prayers with Adhan and Iqama times
+  - A countdown to the next prayer
+  - The Gregorian and Hijri dates
+  - A rotating line of announcements and du'a
+
+The display scales to the terminal size, redraws when the terminal is resized and
+runs until interrupted, fetching the new day's prayer times at midnight.
+
+Announcements come from --announce (repeatable) or kiosk.announcements, and each
+line is shown for --rotate (kiosk.rotate, default 15 seconds):
+
+  pray kiosk --announce "Jumu'ah khutbah at 13:00" --announce "Please silence your phones"
+
+Press Ctrl+C to exit.`,
+	RunE: runKioskCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(kioskCmd)
+
+	kioskCmd.Flags().StringArrayVar(&kioskAnnouncements, "announce", nil, "announcement line to show in rotation (repeatable)")
+	kioskCmd.Flags().DurationVar(&kioskRotate, "rotate", 0, "how long each announcement or du'a is shown (e.g., 20s)")
+}
+
+// kiosk is the state of a running kiosk display. The prayer day is kept up to date
+// by the same countdown used by pray countdown.
+type kiosk struct {
+	clock         *countdown
+	announcements []string
+	rotate        time.Duration
+	theme         *output.Theme
+	l             *output.Localizer
+}
+
+func runKioskCommand(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+
+	source, err := resolveSource()
+	if errors.Is(err, errNoLocation) {
+		fmt.Printf("%sNo location configured. Run 'pray init' or 'pray config detect --save'\n", GetTheme().Prefix("wave"))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	source.useCache()
+
+	// Announcements and rotation: flags > config
+	announcements := cfg.Kiosk.Announcements
+	if len(kioskAnnouncements) > 0 {
+		announcements = kioskAnnouncements
+	}
+	rotate := time.Duration(cfg.Kiosk.Rotate) * time.Second
+	if kioskRotate != 0 {
+		rotate = kioskRotate
+	}
+	if rotate < time.Second {
+		if kioskRotate != 0 {
+			return fmt.Errorf("--rotate must be at least 1s")
+		}
+		rotate = 15 * time.Second
+	}
+
+	day, err := source.fetchDay(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if noColor {
+		color.NoColor = true
+	}
+
+	k := &kiosk{
+		clock:         &countdown{source: source, day: day, fetched: make(chan *prayer.Day, 1)},
+		announcements: announcements,
+		rotate:        rotate,
+		theme:         GetTheme(),
+		l:             newLocalizer(),
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	resizeChan := make(chan os.Signal, 1)
+	notifyResize(resizeChan)
+	defer signal.Stop(resizeChan)
+
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	fmt.Print(enterScreen)
+
+	k.draw()
+	for {
+		select {
+		case <-sigChan:
+			fmt.Print(leaveScreen)
+			fmt.Printf("%s%s\n", k.theme.Prefix("wave"), k.l.T("label.goodbye"))
+			return nil
+		case <-resizeChan:
+			k.draw()
+		case day := <-k.clock.fetched:
+			k.clock.receive(day)
+		case <-ticker.C:
+			k.draw()
+		}
+	}
+}
+
+// draw renders a frame for the current terminal size and time
+func (k *kiosk) draw() {
+	width, height := screenSize()
+	now := k.clock.refresh()
+	drawScreen(k.render(now, width, height))
+}
+
+// render lays out a frame of at most height lines, each centered in width columns.
+// The block-digit clock takes the space left by the other sections, and is replaced
+// by a plain line when it does not fit.
+func (k *kiosk) render(now time.Time, width, height int) []string {
+	th, l := k.theme, k.l
+	day := k.clock.day
+	next := k.clock.next(now)
+
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+	yellow := color.New(color.FgYellow, color.Bold).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+	dim := color.New(color.Faint).SprintFunc()
+
+	center := func(text string, paint func(a ...interface{}) string) string {
+		return centerLine(text, width, paint)
+	}
+
+	// Header: location, Gregorian and Hijri dates
+	header := []string{center(k.clock.source.location, bold)}
+	date := l.DateOf(now)
+	if hijri := l.HijriDate(day.Hijri); hijri != "" {
+		date += "  •  " + hijri
+	}
+	header = append(header, center(date, dim))
+
+	// Next prayer and pending Iqama
+	var nextLines []string
+	if next == nil {
+		nextLines = append(nextLines, center(th.Prefix("night")+"No upcoming prayers", yellow))
+	} else {
+		remaining := l.Digits(formatCountdown(next.Time.Sub(now)))
+		if day.IsLaterDate(next.Time) {
+			nextLines = append(nextLines, center(l.T("status.nextTomorrow", l.Prayer(next.Name), l.Time(next.Time), remaining), yellow))
+		} else {
+			nextLines = append(nextLines, center(prayerPrefix(th, next.Name)+l.Prayer(next.Name)+"  "+l.Time(next.Time), yellow))
+			nextLines = append(nextLines, center(l.T("status.nextPrayer", remaining), yellow))
+		}
+	}
+	if pending := day.PendingIqama(now); pending != nil {
+		iqama := fmt.Sprintf("%s%s %s (%s)", th.Prefix("mosque"), l.Prayer(pending.Name),
+			l.T("label.iqama", l.Time(pending.Iqama)), l.Digits(formatCountdown(pending.Iqama.Sub(now))))
+		nextLines = append(nextLines, center(iqama, green))
+	}
+
+	table := k.table(now, next, width, cyan, yellow, dim)
+
+	var announcement []string
+	if text := k.announcement(now, next); text != "" {
+		for i, line := range output.WrapText(text, width-4) {
+			if i == 2 {
+				break
+			}
+			announcement = append(announcement, center(line, green))
+		}
+	}
+
+	footer := []string{center(day.Method, dim)}
+	if !k.clock.stale.IsZero() {
+		footer = append(footer, center(th.Prefix("warn")+"Offline: today's times are estimated", dim))
+	}
+
+	// The clock gets the rows left after the other sections and the blank lines between them
+	clockText := now.Format("15:04")
+	var suffix string
+	if l.Is12Hour() {
+		clockText = now.Format("3:04")
+		suffix = l.T("time.am")
+		if now.Hour() >= 12 {
+			suffix = l.T("time.pm")
+		}
+	}
+	rest := len(header) + len(nextLines) + len(table) + len(announcement) + len(footer) + 5
+	if suffix != "" {
+		rest++
+	}
+	var clock []string
+	if w := output.BigTextWidth(clockText, 1); w > 0 {
+		scale := min((width-4)/w, (height-rest)/output.BigTextHeight)
+		for _, row := range th.BigText(clockText, scale) {
+			clock = append(clock, center(row, cyan))
+		}
+	}
+	if clock == nil {
+		text := l.Digits(now.Format("15:04:05"))
+		if suffix != "" {
+			text = l.Time(now)
+		}
+		clock = []string{center(text, cyan)}
+	} else if suffix != "" {
+		clock = append(clock, center(suffix, cyan))
+	}
+
+	var lines []string
+	for _, section := range [][]string{header, clock, nextLines, table, announcement, footer} {
+		if len(section) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, section...)
+	}
+
+	// Center vertically, dropping what does not fit from the bottom
+	if len(lines) > height {
+		return lines[:max(height, 0)]
+	}
+	padding := make([]string, (height-len(lines))/2)
+	return append(padding, lines...)
+}
+
+// table returns the day's prayers with their Adhan and Iqama times as a block centered
+// in width, marking the next prayer and dimming those that have passed
+func (k *kiosk) table(now time.Time, next *prayer.Event, width int, heading, highlight, passed func(a ...interface{}) string) []string {
+	th, l := k.theme, k.l
+	day := k.clock.day
+
+	marker := th.Icon("next")
+	if marker == "" {
+		marker = ">"
+	}
+
+	type row struct {
+		cells []string
+		event *prayer.Event
+	}
+	hasIqama := false
+	rows := []row{{cells: []string{l.T("column.prayer"), l.T("column.time")}}}
+	for i := range day.Events {
+		e := &day.Events[i]
+		if e.Name == "Midnight" {
+			continue
+		}
+		r := row{cells: []string{l.Prayer(e.Name), l.Time(e.Time)}, event: e}
+		if e.HasIqama() {
+			r.cells = append(r.cells, l.Time(e.Iqama))
+			hasIqama = true
+		}
+		rows = append(rows, r)
+	}
+	if hasIqama {
+		rows[0].cells = append(rows[0].cells, l.T("column.iqama"))
+	}
+
+	widths := make([]int, 3)
+	for _, r := range rows {
+		for i, cell := range r.cells {
+			widths[i] = max(widths[i], output.DisplayWidth(cell))
+		}
+	}
+
+	texts := make([]string, len(rows))
+	blockWidth := 0
+	for i, r := range rows {
+		prefix := "  "
+		// A combined traveler event starts at the time of its first prayer
+		if r.event != nil && next != nil && r.event.Time.Equal(next.Time) {
+			prefix = marker + " "
+		}
+		var b strings.Builder
+		b.WriteString(prefix)
+		for j, cell := range r.cells {
+			if j > 0 {
+				b.WriteString("    ")
+			}
+			b.WriteString(cell)
+			if j < len(r.cells)-1 {
+				b.WriteString(strings.Repeat(" ", widths[j]-output.DisplayWidth(cell)))
+			}
+		}
+		texts[i] = b.String()
+		blockWidth = max(blockWidth, output.DisplayWidth(texts[i]))
+	}
+
+	indent := strings.Repeat(" ", max((width-blockWidth)/2, 0))
+	lines := make([]string, len(rows))
+	for i, r := range rows {
+		paint := fmt.Sprint
+		switch {
+		case r.event == nil:
+			paint = heading
+		case strings.HasPrefix(texts[i], marker+" "):
+			paint = highlight
+		case r.event.Time.Before(now):
+			paint = passed
+		}
+		lines[i] = indent + paint(texts[i])
+	}
+	return lines
+}
+
+// announcement returns the line shown at now, rotating through the announcements and
+// the du'a that fit the time before the next prayer
+func (k *kiosk) announcement(now time.Time, next *prayer.Event) string {
+	lines := append([]string(nil), k.announcements...)
+
+	category := prayer.DuaDaily
+	if next != nil {
+		category = prayer.DuaCategoryFor(next.Name)
+	}
+	for _, d := range prayer.AdhkarByCategory(category) {
+		text := d.Translation
+		if k.l.Lang() == "ar" {
+			text = d.Arabic
+		}
+		lines = append(lines, text+" — "+d.Source)
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return lines[int(now.Unix()/int64(k.rotate/time.Second))%len(lines)]
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+)
+
+var languagesCmd = &cobra.Command{
+	Use:   "languages",
+	Short: "List available display languages",
+	Long: `Display all languages available for prayer names, headings and labels.
+
+Select a language with the --lang flag or save it with 'pray config set language'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		th := GetTheme()
+
+		fmt.Println()
+		printHeading(th, "globe", "Available Languages", 45)
+		fmt.Println()
+
+		table := tablewriter.NewTable(os.Stdout, tablewriter.WithSymbols(th.Symbols()))
+		table.Header("Code", "Language", "Native", "Fajr", "Native Digits")
+
+		cyan := color.New(color.FgCyan).SprintFunc()
+		current := GetLanguage()
+
+		for _, c := range output.Catalogs() {
+			code := c.Code
+			if code == current {
+				code += " *"
+			}
+			table.Append(cyan(code), c.Name, c.Native, output.NewLocalizer(c.Code, false).Prayer("Fajr"), c.Digits)
+		}
+
+		table.Render()
+		fmt.Println()
+		fmt.Println("* current language")
+		fmt.Println()
+		fmt.Println("Use -l or --lang flag to select a language:")
+		fmt.Println("  pray -l tr                Use Turkish")
+		fmt.Println("  pray config set language ur")
+		fmt.Println("  pray --lang ar --numerals native")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(languagesCmd)
+}
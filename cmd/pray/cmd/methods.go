@@ -42,13 +42,14 @@ prayer times based on specific astronomical angles.`,
 			return
 		}
 
+		th := GetTheme()
+
 		fmt.Println()
-		fmt.Println("📐 Available Calculation Methods")
-		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		printHeading(th, "methods", "Available Calculation Methods", 45)
 		fmt.Println()
 
 		// Create table with new API
-		table := tablewriter.NewTable(os.Stdout)
+		table := tablewriter.NewTable(os.Stdout, tablewriter.WithSymbols(th.Symbols()))
 		table.Header("ID", "Name", "Description")
 
 		cyan := color.New(color.FgCyan).SprintFunc()
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/config"
+	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+var (
+	mosqueTimezone string
+	mosqueOffsets  string
+	mosqueFixed    string
+	mosqueRound    int
+	mosqueWeekly   []string
+	mosqueJumuah   string
+)
+
+var mosqueCmd = &cobra.Command{
+	Use:   "mosque",
+	Short: "Mosque profile management",
+	Long: `Manage mosque profiles with their own location, Iqama schedule and Jumu'ah times.
+
+Use --mosque <name> with any command to use a profile once, or
+'pray mosque use <name>' to make it the default.`,
+}
+
+var mosqueAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or update a mosque profile",
+	Long: `Add or update a mosque profile.
+
+The mosque location is taken from the --lat and --lon flags. Iqama times
+default to offsets after the Adhan, can be pinned to fixed clock times,
+and can be overridden per weekday with --weekly.
+
+Examples:
+  pray mosque add "Masjid Noor" --lat 30.05 --lon 31.24 --jumuah "13:00,14:15"
+  pray mosque add "Masjid Noor" --lat 30.05 --lon 31.24 --fixed "Isha: 20:30" --round 5
+  pray mosque add "Masjid Noor" --lat 30.05 --lon 31.24 --weekly "sat=Fajr: 05:30" --weekly "fri=Isha: 21:00"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMosqueAdd,
+}
+
+var mosqueListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List saved mosque profiles",
+	RunE:    runMosqueList,
+}
+
+var mosqueUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the default mosque profile",
+	Long: `Set the default mosque profile used by all commands.
+
+Use 'pray mosque use none' to go back to the configured location.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMosqueUse,
+}
+
+var mosqueRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a mosque profile",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runMosqueRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(mosqueCmd)
+	mosqueCmd.AddCommand(mosqueAddCmd)
+	mosqueCmd.AddCommand(mosqueListCmd)
+	mosqueCmd.AddCommand(mosqueUseCmd)
+	mosqueCmd.AddCommand(mosqueRemoveCmd)
+
+	mosqueAddCmd.Flags().StringVar(&mosqueTimezone, "tz", "", "mosque timezone (e.g., 'Africa/Cairo')")
+	mosqueAddCmd.Flags().StringVar(&mosqueOffsets, "offsets", "", "Iqama offsets after Adhan (e.g., '15,0,10,10,5,10,0')")
+	mosqueAddCmd.Flags().StringVar(&mosqueFixed, "fixed", "", "fixed Iqama times (e.g., 'Isha: 20:30, Fajr: 05:00')")
+	mosqueAddCmd.Flags().IntVar(&mosqueRound, "round", 0, "round Iqama times to the nearest N minutes")
+	mosqueAddCmd.Flags().StringArrayVar(&mosqueWeekly, "weekly", nil, "weekday fixed Iqama times (e.g., 'fri=Isha: 21:00'), repeatable")
+	mosqueAddCmd.Flags().StringVar(&mosqueJumuah, "jumuah", "", "Jumu'ah khutbah times (e.g., '13:00,14:15')")
+}
+
+func runMosqueAdd(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	green := color.New(color.FgGreen).SprintFunc()
+
+	name := strings.TrimSpace(args[0])
+	if strings.EqualFold(name, "none") {
+		return fmt.Errorf("'none' is reserved, choose another mosque name")
+	}
+
+	// Start from the existing profile so updates keep unspecified fields
+	mosque := config.MosqueConfig{Name: name}
+	if existing := cfg.GetMosque(name); existing != nil {
+		mosque = *existing
+	}
+
+	if latitude != 0 || longitude != 0 {
+		if err := config.ValidateCoordinates(latitude, longitude); err != nil {
+			return err
+		}
+		mosque.Location = *location.FromCoordinates(latitude, longitude)
+		mosque.Location.Address = name
+	}
+	if address != "" {
+		mosque.Location.Address = address
+	}
+	if mosqueTimezone != "" {
+		mosque.Location.Timezone = mosqueTimezone
+	}
+	if !mosque.Location.IsValid() {
+		return fmt.Errorf("mosque location is required: use --lat and --lon")
+	}
+
+	if mosqueOffsets != "" {
+		mosque.Iqama.Offsets = mosqueOffsets
+	}
+	if mosqueFixed != "" {
+		mosque.Iqama.Fixed = mosqueFixed
+	}
+	if cmd.Flags().Changed("round") {
+		mosque.Iqama.Round = mosqueRound
+	}
+
+	for _, entry := range mosqueWeekly {
+		day, fixed, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid --weekly value %q (expected 'day=Prayer: HH:MM')", entry)
+		}
+		weekday, err := config.ParseWeekday(day)
+		if err != nil {
+			return err
+		}
+		if mosque.Weekly == nil {
+			mosque.Weekly = make(map[string]config.MosqueIqama)
+		}
+		mosque.Weekly[strings.ToLower(weekday.String())] = config.MosqueIqama{Fixed: strings.TrimSpace(fixed)}
+	}
+
+	if mosqueJumuah != "" {
+		times, err := prayer.ParseJumuahTimes(mosqueJumuah)
+		if err != nil {
+			return err
+		}
+		mosque.Jumuah = times
+	}
+
+	cfg.AddMosque(mosque)
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("%sSaved mosque: %s\n", green(GetTheme().Prefix("ok")), name)
+	if !strings.EqualFold(cfg.Mosque, name) {
+		fmt.Printf("  Run 'pray mosque use \"%s\"' to make it the default.\n", name)
+	}
+	return nil
+}
+
+func runMosqueList(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+
+	if len(cfg.Mosques) == 0 {
+		fmt.Println("No mosques saved. Add one with 'pray mosque add <name> --lat <lat> --lon <lon>'.")
+		return nil
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	th := GetTheme()
+
+	fmt.Println()
+	printHeading(th, "mosque", "Saved Mosques", 45)
+	fmt.Println()
+
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithSymbols(th.Symbols()))
+	table.Header("", "Name", "Location", "Iqama", "Jumu'ah")
+
+	for _, m := range cfg.Mosques {
+		active := ""
+		name := m.Name
+		if strings.EqualFold(cfg.Mosque, m.Name) {
+			active = th.Icon("next")
+			if active == "" {
+				active = "*"
+			}
+			name = cyan(m.Name)
+		}
+
+		iqama := describeMosqueIqama(m)
+		jumuah := strings.Join(m.Jumuah, ", ")
+		if jumuah == "" {
+			jumuah = "-"
+		}
+
+		table.Append(active, name, fmt.Sprintf("%.4f, %.4f", m.Location.Latitude, m.Location.Longitude), iqama, jumuah)
+	}
+
+	table.Render()
+	fmt.Println()
+	return nil
+}
+
+func runMosqueUse(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	green := color.New(color.FgGreen).SprintFunc()
+
+	name := args[0]
+	if strings.EqualFold(name, "none") {
+		cfg.Mosque = ""
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("%sNo default mosque, using configured location\n", green(GetTheme().Prefix("ok")))
+		return nil
+	}
+
+	m := cfg.GetMosque(name)
+	if m == nil {
+		return fmt.Errorf("unknown mosque: %s (run 'pray mosque list' to see saved mosques)", name)
+	}
+
+	cfg.Mosque = m.Name
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("%sDefault mosque set to: %s\n", green(GetTheme().Prefix("ok")), m.Name)
+	return nil
+}
+
+func runMosqueRemove(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	green := color.New(color.FgGreen).SprintFunc()
+
+	if !cfg.RemoveMosque(args[0]) {
+		return fmt.Errorf("unknown mosque: %s", args[0])
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("%sRemoved mosque: %s\n", green(GetTheme().Prefix("ok")), args[0])
+	return nil
+}
+
+// describeMosqueIqama returns a short summary of a mosque's Iqama rules
+func describeMosqueIqama(m config.MosqueConfig) string {
+	var parts []string
+	if m.Iqama.Offsets != "" {
+		parts = append(parts, "offsets "+m.Iqama.Offsets)
+	}
+	if m.Iqama.Fixed != "" {
+		parts = append(parts, m.Iqama.Fixed)
+	}
+	if m.Iqama.Round > 0 {
+		parts = append(parts, fmt.Sprintf("round %dm", m.Iqama.Round))
+	}
+	if len(m.Weekly) > 0 {
+		parts = append(parts, fmt.Sprintf("%d weekly overrides", len(m.Weekly)))
+	}
+	if len(parts) == 0 {
+		return "default"
+	}
+	return strings.Join(parts, "; ")
+}
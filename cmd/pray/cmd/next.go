@@ -1,147 +1,97 @@
 package cmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
-	"github.com/AbdElrahmaN31/pray-cli/internal/api"
 	"github.com/AbdElrahmaN31/pray-cli/internal/config"
-	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 var nextCmd = &cobra.Command{
 	Use:   "next",
 	Short: "Show the next prayer",
-	Long:  `Display information about the next upcoming prayer time.`,
-	RunE:  runNextCommand,
+	Long: `Display information about the next upcoming prayer time.
+
+Use --count to list several upcoming prayers, continuing into the following days.`,
+	RunE: runNextCommand,
 }
 
+var nextCount int
+
 func init() {
 	rootCmd.AddCommand(nextCmd)
+
+	nextCmd.Flags().IntVarP(&nextCount, "count", "n", 1, "list the next N prayers, continuing into the following days")
 }
 
 func runNextCommand(cmd *cobra.Command, args []string) error {
-	cfg := GetConfig()
-
-	// Determine location
-	var lat, lon float64
-	var locationStr string
-	var tz string
-
-	// Priority: flags > config
-	if autoDetect {
-		detector := location.NewDetector()
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel()
-
-		loc, err := detector.DetectFromIP(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to auto-detect location: %w", err)
-		}
-		lat = loc.Latitude
-		lon = loc.Longitude
-		locationStr = loc.GetDisplayAddress()
-		tz = loc.Timezone
-	} else if address != "" {
-		locationStr = address
-	} else if latitude != 0 || longitude != 0 {
-		lat = latitude
-		lon = longitude
-		locationStr = fmt.Sprintf("%.4f, %.4f", lat, lon)
-	} else if cfg.IsConfigured() {
-		lat = cfg.Location.Latitude
-		lon = cfg.Location.Longitude
-		locationStr = cfg.Location.GetDisplayAddress()
-		tz = cfg.Location.Timezone
-	} else {
-		fmt.Println("👋 No location configured. Run 'pray init' or 'pray config detect --save'")
-		return nil
+	if nextWait {
+		waitUntil = "next"
+		return runWaitCommand(cmd, args)
 	}
-
-	// Determine method
-	methodID := cfg.Method
-	if method != 0 {
-		methodID = method
+	if cmd.Flags().Changed("before") {
+		return fmt.Errorf("--before requires --wait")
+	}
+	if nextCount < 1 {
+		return fmt.Errorf("count must be at least 1")
 	}
 
-	// Create API client
-	client := api.NewClient(api.WithTimeout(time.Duration(cfg.APITimeout) * time.Second))
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.APITimeout)*time.Second)
-	defer cancel()
-
-	// Build params
-	params := api.NewPrayerTimesParams().
-		WithDate(time.Now()).
-		WithMethod(methodID)
-
-	var resp *api.PrayerTimesResponse
-	var err error
-
-	if address != "" {
-		params.WithAddress(address)
-		resp, err = client.GetPrayerTimesByAddress(ctx, params)
-	} else {
-		params.WithCoordinates(lat, lon)
-		if tz != "" {
-			params.WithTimezone(tz)
-		}
-		resp, err = client.GetPrayerTimes(ctx, params)
+	// Priority: flags > mosque profile > config
+	source, err := resolveSource()
+	if errors.Is(err, errNoLocation) {
+		fmt.Printf("%sNo location configured. Run 'pray init' or 'pray config detect --save'\n", GetTheme().Prefix("wave"))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if source.travelNotice != "" && !IsQuiet() {
+		fmt.Fprintf(os.Stderr, "%s%s\n", GetTheme().Prefix("traveler"), source.travelNotice)
 	}
+	source.useCache()
+	locationStr, methodID, mosque := source.location, source.methodID, source.mosque
 
+	// Normalized day in the location's timezone, with Iqama, Jumu'ah and traveler settings
+	day, err := source.fetchDay(time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to fetch prayer times: %w", err)
+		return err
 	}
+	now := time.Now().In(day.Date.Location())
+	events := day.Events
 
-	// Get current time
-	now := time.Now()
-	if tz != "" {
-		loc, err := time.LoadLocation(tz)
-		if err == nil {
-			now = time.Now().In(loc)
+	if cmd.Flags().Changed("count") {
+		// Fetch enough following days for the list, beyond a week they are estimated
+		perDay := max(len(day.Events)-1, 1)
+		if err := fetchFollowingDays(source.fetcher(), source.params, day, min(nextCount/perDay+1, 7), mosque); err != nil && !IsQuiet() {
+			fmt.Fprintf(os.Stderr, "%sCould not fetch the following days, their times are estimated: %v\n", GetTheme().Prefix("warn"), err)
 		}
+		return printUpcoming(day.Upcoming(now, nextCount), now, locationStr, methodID)
 	}
 
-	// Find next prayer
-	timings := resp.Data.Timings
-	prayers := []struct {
-		name  string
-		time  string
-		emoji string
-	}{
-		{"Fajr", cleanTime(timings.Fajr), "🌅"},
-		{"Sunrise", cleanTime(timings.Sunrise), "🌄"},
-		{"Dhuhr", cleanTime(timings.Dhuhr), "☀️"},
-		{"Asr", cleanTime(timings.Asr), "🌤️"},
-		{"Maghrib", cleanTime(timings.Maghrib), "🌆"},
-		{"Isha", cleanTime(timings.Isha), "🌙"},
-	}
-
-	var nextPrayer *struct {
-		name       string
-		time       string
-		emoji      string
-		prayerTime time.Time
-	}
-
-	for _, p := range prayers {
-		prayerTime, err := parseTimeForToday(p.time, now)
-		if err != nil {
-			continue
-		}
-		if now.Before(prayerTime) {
-			nextPrayer = &struct {
-				name       string
-				time       string
-				emoji      string
-				prayerTime time.Time
-			}{p.name, p.time, p.emoji, prayerTime}
-			break
-		}
+	// Traveler mode combines prayers into a single event at the time of the first prayer
+	var activeWindow prayer.CombinedWindow
+	var windowEnd time.Time
+	var inWindow bool
+	if day.Traveler {
+		events = day.Combined()
+		activeWindow, windowEnd, inWindow = day.ActiveWindow(now)
+	}
+
+	// After Isha the next prayer is tomorrow's Fajr
+	if prayer.NextEvent(events, now) == nil {
+		attachFollowingDay(source.fetcher(), source.params, day, now, mosque)
 	}
+	nextPrayer := day.NextPrayer(now)
+	tomorrow := nextPrayer != nil && day.IsLaterDate(nextPrayer.Time)
+	pending := day.PendingIqama(now)
 
 	// Colors
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -153,84 +103,237 @@ func runNextCommand(cmd *cobra.Command, args []string) error {
 		color.NoColor = true
 	}
 
+	// Friday reminders
+	var reminders []string
+	if isFridayEnabled(mosque) {
+		reminders = buildFridayReminders(day, now)
+	}
+
+	// Du'a for the upcoming prayer window
+	var dua *prayer.Dua
+	if ShouldShowDua() {
+		next := ""
+		if nextPrayer != nil {
+			next = nextPrayer.Name
+		}
+		dua = prayer.SelectDua(now, next)
+	}
+
 	// Output based on format
-	if outputFormat == "json" {
+	if isJSONOutput() {
+		var out *output.NextOutput
 		if nextPrayer != nil {
-			mins := int(time.Until(nextPrayer.prayerTime).Minutes())
-			fmt.Printf(`{"name":"%s","time":"%s","minutesUntil":%d,"location":"%s"}%s`,
-				nextPrayer.name, nextPrayer.time, mins, locationStr, "\n")
-		} else {
-			fmt.Println(`{"name":null,"message":"All prayers for today have passed"}`)
+			out = &output.NextOutput{
+				NextPrayerOutput: output.NextPrayerOutput{
+					Name:         nextPrayer.Name,
+					Time:         nextPrayer.Clock(),
+					Date:         nextPrayer.Time.Format("2006-01-02"),
+					Tomorrow:     tomorrow,
+					MinutesUntil: int(nextPrayer.Time.Sub(now).Minutes()),
+				},
+				Reminders: reminders,
+				Dua:       dua,
+				Location:  locationStr,
+			}
+			if nextPrayer.HasIqama() {
+				out.Iqama = nextPrayer.Iqama.Format("15:04")
+			}
+			if pending != nil {
+				out.PendingIqama = &output.PendingIqamaOutput{
+					Name:         pending.Name,
+					Time:         pending.Iqama.Format("15:04"),
+					MinutesUntil: int(pending.Iqama.Sub(now).Minutes()),
+				}
+			}
+			if inWindow {
+				out.CombinedWindow = &output.WindowOutput{
+					Name:         activeWindow.Name,
+					End:          activeWindow.End,
+					MinutesUntil: int(windowEnd.Sub(now).Minutes()),
+				}
+			}
 		}
-		return nil
+		return printJSON(out)
 	}
 
 	// Pretty output
+	l := newLocalizer()
+	th := GetTheme()
 	fmt.Println()
+	if pending != nil {
+		mins := int(pending.Iqama.Sub(now).Minutes())
+		fmt.Printf("%s%s\n", th.Prefix("mosque"), yellow(l.T("next.pendingIqama", l.Prayer(pending.Name), l.Duration(mins), l.Time(pending.Iqama))))
+		fmt.Println()
+	}
+	if inWindow {
+		mins := int(windowEnd.Sub(now).Minutes())
+		fmt.Printf("%s%s\n", th.Prefix("traveler"), yellow(l.T("next.windowOpen", l.Prayer(activeWindow.Name), l.Clock(activeWindow.End), l.Duration(mins))))
+		fmt.Println()
+	}
 	if nextPrayer == nil {
-		fmt.Println("🌙 All prayers for today have passed")
-		fmt.Printf("   Tomorrow's Fajr: %s\n", cleanTime(timings.Fajr))
+		fmt.Printf("%s%s\n", th.Prefix("night"), l.T("next.none"))
 	} else {
-		mins := int(time.Until(nextPrayer.prayerTime).Minutes())
+		mins := int(nextPrayer.Time.Sub(now).Minutes())
 
-		fmt.Printf("%s %s\n", nextPrayer.emoji, cyan(fmt.Sprintf("Next Prayer: %s", nextPrayer.name)))
-		fmt.Printf("   Time: %s\n", green(nextPrayer.time))
-		fmt.Printf("   In:   %s\n", yellow(formatMinutesLong(mins)))
+		if tomorrow {
+			fmt.Printf("%s%s\n", th.Prefix("night"), l.T("next.allPassed"))
+			fmt.Println()
+		}
+		fmt.Printf("%s%s\n", prayerPrefix(th, nextPrayer.Name), cyan(l.T("next.title", l.Prayer(nextPrayer.Name))))
+		if tomorrow {
+			fmt.Printf("   %s %s\n", l.T("next.time", green(l.Time(nextPrayer.Time))), dim(l.T("next.tomorrow")))
+		} else {
+			fmt.Printf("   %s\n", l.T("next.time", green(l.Time(nextPrayer.Time))))
+		}
+		fmt.Printf("   %s\n", l.T("next.in", yellow(l.Duration(mins))))
+		if nextPrayer.HasIqama() {
+			iqamaMins := int(nextPrayer.Iqama.Sub(now).Minutes())
+			fmt.Printf("   %s %s\n", l.T("next.iqama", green(l.Time(nextPrayer.Iqama))), dim(l.T("next.iqamaIn", l.Duration(iqamaMins))))
+		}
+		fmt.Println()
+		fmt.Printf("   %s\n", dim(l.T("label.location", locationStr)))
+		fmt.Printf("   %s\n", dim(l.T("label.method", config.GetMethodName(methodID))))
+	}
+	if len(reminders) > 0 {
+		fmt.Println()
+		for _, r := range reminders {
+			fmt.Printf("%s%s\n", th.Prefix("reminder"), r)
+		}
+	}
+	if dua != nil {
 		fmt.Println()
-		fmt.Printf("   %s\n", dim(fmt.Sprintf("Location: %s", locationStr)))
-		fmt.Printf("   %s\n", dim(fmt.Sprintf("Method: %s", config.GetMethodName(methodID))))
+		fmt.Printf("%s%s\n", th.Prefix("dua"), cyan(dua.Title()))
+		fmt.Printf("   %s\n", green(dua.Arabic))
+		fmt.Printf("   \"%s\" %s\n", dua.Translation, dim("— "+dua.Source))
 	}
 	fmt.Println()
 
 	return nil
 }
 
-// cleanTime removes timezone info from time string
-func cleanTime(timeStr string) string {
-	for i, c := range timeStr {
-		if c == ' ' || c == '(' {
-			return timeStr[:i]
+// printUpcoming prints a list of upcoming prayers with their time, time remaining and day
+func printUpcoming(events []prayer.Event, now time.Time, locationStr string, methodID int) error {
+	if isJSONOutput() {
+		// Day labels stay in English, like the rest of the JSON output
+		en := output.NewLocalizer("en", false)
+		prayers := make([]output.UpcomingPrayerOutput, 0, len(events))
+		for _, e := range events {
+			p := output.UpcomingPrayerOutput{
+				Name:         e.Name,
+				Time:         e.Clock(),
+				Date:         e.Time.Format("2006-01-02"),
+				Day:          dayLabel(en, e.Time, now),
+				ISO:          e.Time.Format(time.RFC3339),
+				MinutesUntil: int(e.Time.Sub(now).Minutes()),
+			}
+			if e.HasIqama() {
+				p.Iqama = e.Iqama.Format("15:04")
+			}
+			prayers = append(prayers, p)
 		}
+		return printJSON(&output.UpcomingOutput{
+			Prayers:  prayers,
+			Location: locationStr,
+			Method:   config.GetMethodName(methodID),
+		})
 	}
-	return timeStr
-}
 
-// parseTimeForToday parses a time string and returns time.Time for today
-func parseTimeForToday(timeStr string, now time.Time) (time.Time, error) {
-	var hour, minute int
-	_, err := fmt.Sscanf(timeStr, "%d:%d", &hour, &minute)
-	if err != nil {
-		return time.Time{}, err
+	l := newLocalizer()
+	th := GetTheme()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	dim := color.New(color.Faint).SprintFunc()
+
+	if noColor {
+		color.NoColor = true
+	}
+
+	hasIqama := false
+	for _, e := range events {
+		hasIqama = hasIqama || e.HasIqama()
 	}
-	return time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location()), nil
-}
 
-// formatMinutesLong formats minutes in a longer human-readable format
-func formatMinutesLong(mins int) string {
-	if mins < 0 {
-		return "passed"
+	if outputFormat == "table" {
+		table := tablewriter.NewTable(os.Stdout, tablewriter.WithSymbols(th.Symbols()))
+		header := []any{l.T("column.prayer"), l.T("column.time")}
+		if hasIqama {
+			header = append(header, l.T("column.iqama"))
+		}
+		table.Header(append(header, l.T("column.in"), l.T("column.day"))...)
+		for _, e := range events {
+			row := []any{l.Prayer(e.Name), l.Time(e.Time)}
+			if hasIqama {
+				iqama := ""
+				if e.HasIqama() {
+					iqama = l.Time(e.Iqama)
+				}
+				row = append(row, iqama)
+			}
+			table.Append(append(row, l.Duration(int(e.Time.Sub(now).Minutes())), dayLabel(l, e.Time, now))...)
+		}
+		return table.Render()
+	}
+
+	fmt.Println()
+	printHeading(th, "next", cyan(l.T("next.count", len(events))), 44)
+	names := make([]string, len(events))
+	iqamas := make([]string, len(events))
+	until := make([]string, len(events))
+	nameWidth, iqamaWidth, width := 0, 0, 0
+	for i, e := range events {
+		names[i] = l.Prayer(e.Name)
+		if e.HasIqama() {
+			iqamas[i] = l.T("next.iqamaAt", l.Time(e.Iqama))
+		}
+		until[i] = l.T("next.until", l.Duration(int(e.Time.Sub(now).Minutes())))
+		nameWidth = max(nameWidth, output.DisplayWidth(names[i]))
+		iqamaWidth = max(iqamaWidth, output.DisplayWidth(iqamas[i]))
+		width = max(width, output.DisplayWidth(until[i]))
 	}
-	if mins < 60 {
-		if mins == 1 {
-			return "1 minute"
+	for i, e := range events {
+		line := fmt.Sprintf("%s%s  %s", prayerPrefix(th, e.Name), output.PadRight(names[i], nameWidth), green(l.Time(e.Time)))
+		if hasIqama {
+			line += " " + dim(output.PadRight(iqamas[i], iqamaWidth))
 		}
-		return fmt.Sprintf("%d minutes", mins)
+		fmt.Printf("   %s  %s  %s\n", line, yellow(output.PadRight(until[i], width)), dim(dayLabel(l, e.Time, now)))
 	}
-	hours := mins / 60
-	remaining := mins % 60
+	fmt.Println()
+	fmt.Printf("   %s\n", dim(l.T("label.location", locationStr)))
+	fmt.Printf("   %s\n", dim(l.T("label.method", config.GetMethodName(methodID))))
+	fmt.Println()
+
+	return nil
+}
 
-	hourStr := "hour"
-	if hours > 1 {
-		hourStr = "hours"
+// dayLabel returns "Today", "Tomorrow" or the weekday and date of t relative to now,
+// in the localizer's language
+func dayLabel(l *output.Localizer, t, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch int(date.Sub(today).Hours() / 24) {
+	case 0:
+		return l.T("day.today")
+	case 1:
+		return l.T("day.tomorrow")
 	}
+	return l.ShortDate(t)
+}
 
-	if remaining == 0 {
-		return fmt.Sprintf("%d %s", hours, hourStr)
+// prayerPrefix returns the theme's icon for a prayer followed by a space, or "" when it has none
+func prayerPrefix(th *output.Theme, name string) string {
+	if icon := th.PrayerIcon(name); icon != "" {
+		return icon + " "
 	}
+	return ""
+}
 
-	minStr := "minute"
-	if remaining > 1 {
-		minStr = "minutes"
+// cleanTime removes timezone info from time string
+func cleanTime(timeStr string) string {
+	for i, c := range timeStr {
+		if c == ' ' || c == '(' {
+			return timeStr[:i]
+		}
 	}
-	return fmt.Sprintf("%d %s %d %s", hours, hourStr, remaining, minStr)
+	return timeStr
 }
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/config"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+)
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print a short next-prayer segment for shell prompts",
+	Long: `Print a short segment for a shell prompt, e.g. "Asr 42m".
+
+pray prompt is meant to run on every prompt, so it reads prayer times from the
+response cache only: it never makes a network request or checks for updates.
+Run 'pray' once a day (or from cron) to keep the cache filled.
+
+It has a hard latency budget (--timeout, or prompt.timeout in the config,
+100ms by default). When the budget is exceeded, nothing is cached or anything
+else fails, it prints nothing and exits 0, so the prompt is never broken or
+slowed down. Use --verbose to see why nothing was printed.
+
+The segment is a Go template with the fields and helpers of the template
+format (see pray --help): --template, --template-file, prompt.format in the
+config, or by default:
+
+  ` + config.DefaultPromptFormat,
+	Example: `  # bash
+  PS1='$(pray prompt) \w \$ '
+
+  # zsh
+  setopt PROMPT_SUBST
+  PROMPT='$(pray prompt) %~ %# '
+
+  # starship (custom module)
+  [custom.pray]
+  command = "pray prompt"
+  when = true
+
+  pray prompt --template '{{with .Next}}🕌 {{.Label}} {{clock .Time}}{{end}}'`,
+	RunE: runPromptCommand,
+}
+
+var promptTimeout time.Duration
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+
+	promptCmd.Flags().DurationVar(&promptTimeout, "timeout", 0, "print nothing when the segment takes longer (default: prompt.timeout or 100ms)")
+}
+
+func runPromptCommand(cmd *cobra.Command, args []string) error {
+	// The prompt shows nothing rather than an error or usage text
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	if autoDetect {
+		promptSkipped(errAutoDetectCacheOnly)
+		return nil
+	}
+
+	budget := promptTimeout
+	if budget <= 0 {
+		budget = time.Duration(GetConfig().Prompt.Timeout) * time.Millisecond
+	}
+	if budget <= 0 {
+		budget = 100 * time.Millisecond
+	}
+
+	segment, err := runWithin(budget, promptSegment)
+	if err != nil {
+		promptSkipped(err)
+		return nil
+	}
+	_, err = os.Stdout.Write(segment)
+	return err
+}
+
+// runWithin returns the result of work, or an error once budget has passed without
+// waiting for work to finish
+func runWithin(budget time.Duration, work func() ([]byte, error)) ([]byte, error) {
+	type result struct {
+		segment []byte
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		segment, err := work()
+		done <- result{segment, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.segment, r.err
+	case <-time.After(budget):
+		return nil, fmt.Errorf("timed out after %s", budget)
+	}
+}
+
+// promptSegment renders the prompt template against the cached prayer times, without the
+// template format's trailing newline, which would break the prompt line
+func promptSegment() ([]byte, error) {
+	tmpl, err := promptTemplate()
+	if err != nil {
+		return nil, err
+	}
+	data, err := cachedPrayerData()
+	if err != nil {
+		return nil, err
+	}
+	data.Template = tmpl
+
+	var buf bytes.Buffer
+	if err := (&output.TemplateFormatter{}).Format(&buf, data); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// promptTemplate returns the prompt template: --template-file > --template > config > default
+func promptTemplate() (string, error) {
+	if templateFile != "" || templateText != "" {
+		return GetTemplate()
+	}
+	if tmpl := strings.TrimSpace(GetConfig().Prompt.Format); tmpl != "" {
+		return tmpl, nil
+	}
+	return config.DefaultPromptFormat, nil
+}
+
+// promptSkipped reports why the prompt printed nothing when --verbose is set
+func promptSkipped(err error) {
+	if IsVerbose() {
+		fmt.Fprintf(os.Stderr, "pray prompt: %v\n", err)
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPromptColdCache(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home+"/.config")
+	t.Setenv("XDG_CACHE_HOME", home+"/.cache")
+	t.Cleanup(func() {
+		latitude, longitude, autoDetect, promptTimeout = 0, 0, false, 0
+		rootCmd.SetArgs(nil)
+	})
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"nothing cached", []string{"prompt", "--lat", "30", "--lon", "31"}},
+		{"no location", []string{"prompt"}},
+		{"auto-detected location", []string{"prompt", "--auto"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latitude, longitude, autoDetect = 0, 0, false
+			rootCmd.SetArgs(append(tt.args, "--timeout", "100ms"))
+
+			var err error
+			start := time.Now()
+			out := captureStdout(t, func() { err = rootCmd.Execute() })
+			elapsed := time.Since(start)
+
+			if err != nil {
+				t.Errorf("pray %v error = %v, want nil (exit 0)", tt.args, err)
+			}
+			if out != "" {
+				t.Errorf("pray %v printed %q, want nothing", tt.args, out)
+			}
+			// The budget itself is tested by TestRunWithin; this bound only catches a
+			// network fetch or IP lookup, which would take seconds
+			if elapsed > 5*time.Second {
+				t.Errorf("pray %v took %v, it should not go to the network", tt.args, elapsed)
+			}
+		})
+	}
+}
+
+func TestRunWithin(t *testing.T) {
+	segment, err := runWithin(time.Minute, func() ([]byte, error) { return []byte("Asr 42m"), nil })
+	if err != nil || string(segment) != "Asr 42m" {
+		t.Errorf("runWithin() = %q, %v, want \"Asr 42m\"", segment, err)
+	}
+
+	failed := errors.New("failed")
+	if _, err := runWithin(time.Minute, func() ([]byte, error) { return nil, failed }); !errors.Is(err, failed) {
+		t.Errorf("runWithin() error = %v, want %v", err, failed)
+	}
+
+	// Slow work is abandoned once the budget has passed
+	release := make(chan struct{})
+	defer close(release)
+	start := time.Now()
+	segment, err = runWithin(20*time.Millisecond, func() ([]byte, error) {
+		<-release
+		return []byte("late"), nil
+	})
+	if err == nil || segment != nil {
+		t.Errorf("runWithin() of slow work = %q, %v, want a timeout", segment, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("runWithin() returned after %v, want about 20ms", elapsed)
+	}
+}
+
+// captureStdout returns what run writes to os.Stdout
+func captureStdout(t *testing.T, run func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	run()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
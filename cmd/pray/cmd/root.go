@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -12,7 +14,10 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/AbdElrahmaN31/pray-cli/internal/config"
+	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
 	"github.com/AbdElrahmaN31/pray-cli/internal/update"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 var (
@@ -28,18 +33,27 @@ var (
 	noColor      bool
 	outputFormat string
 	outputFile   string
+	templateText string
+	templateFile string
+	delimiter    string
+	dateFormat   string
+	htmlTheme    string
 
 	// Location flags
 	address    string
 	latitude   float64
 	longitude  float64
 	autoDetect bool
+	mosqueName string
 
 	// Calculation flags
 	method int
 
 	// Display flags
 	language    string
+	numerals    string
+	timeFormat  string
+	themeName   string
 	showQibla   bool
 	showDua     bool
 	hijriFormat string
@@ -48,6 +62,10 @@ var (
 	travelerMode bool
 	jumuahMode   bool
 	ramadanMode  bool
+	showIqama    bool
+
+	// Set when an auto-detected location is far from home
+	autoTraveler bool
 
 	// Config management flags
 	saveConfig   bool
@@ -83,13 +101,27 @@ Get started with:
 			color.NoColor = true
 		}
 
+		// Validate the language flag against the available catalogs
+		if language != "" {
+			catalog := output.GetCatalog(language)
+			if catalog == nil {
+				return fmt.Errorf("unknown language: %s (available: %s)", language, strings.Join(output.Languages(), ", "))
+			}
+			language = catalog.Code
+		}
+		if timeFormat != "" && !slices.Contains(config.DefaultTimeFormats, timeFormat) {
+			return fmt.Errorf("invalid time format: %s (must be 24h, 12h, or locale)", timeFormat)
+		}
+		if themeName != "" && !slices.Contains(output.ThemeNames(), themeName) {
+			return fmt.Errorf("invalid theme: %s (available: %s)", themeName, strings.Join(output.ThemeNames(), ", "))
+		}
+
 		return initConfig()
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
 		// Check for updates if enabled in config
 		if cfg != nil && cfg.UpdateCheck && !quiet {
-			// Skip for certain commands
-			if cmd.Name() == "version" || cmd.Name() == "completion" || cmd.Name() == "init" {
+			if skipUpdateCheck(cmd) {
 				return
 			}
 
@@ -114,38 +146,89 @@ Get started with:
 	},
 }
 
+// skipUpdateCheck reports whether a command skips the update check: commands that do
+// not load the config, prompts and status bars, which run every few seconds and must not
+// block, pray wait (or pray next --wait), whose exit usually starts another command at
+// once, and machine-readable output, which the update message would corrupt
+func skipUpdateCheck(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case "version", "completion", "init", "prompt", "wait":
+		return true
+	case "next":
+		if nextWait {
+			return true
+		}
+	}
+	format := GetOutputFormat()
+	return output.IsStatusFormat(format) || output.IsMachineFormat(format)
+}
+
 // Execute runs the root command
 func Execute() error {
 	return rootCmd.Execute()
 }
 
+// ExitError makes the command exit with Code, printing Err unless it is nil
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
 // SetVersionInfo sets the version information from build flags
 func SetVersionInfo(v, c, d string) {
 	version = v
 	commit = c
 	date = d
+	output.Version = v
 }
 
 func init() {
+	// The config accepts the languages of the output catalogs and the output themes
+	config.SetChoices(config.Choices{
+		Languages:  output.Languages(),
+		Themes:     output.ThemeNames(),
+		HTMLThemes: output.HTMLThemes,
+	})
+
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/pray/config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output (show debug info)")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "minimal output (errors only)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "output format: table/pretty/json/slack/discord")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "output format: table/pretty/json/slack/discord/webhook/template/csv/tsv/markdown/html/yaml/xml/ndjson/waybar/i3bar/polybar/tmux")
 	rootCmd.PersistentFlags().StringVarP(&outputFile, "file", "f", "", "save output to file")
+	rootCmd.PersistentFlags().StringVar(&templateText, "template", "", "Go template for -o template (e.g., '{{.Next.Name}} in {{.Next.In}}')")
+	rootCmd.PersistentFlags().StringVar(&templateFile, "template-file", "", "read the Go template for -o template from a file")
+	rootCmd.PersistentFlags().StringVar(&delimiter, "delimiter", "", "field delimiter for -o csv: a single character or \"tab\"")
+	rootCmd.PersistentFlags().StringVar(&dateFormat, "date-format", "", "Go layout of dates in csv/tsv output (e.g., 02/01/2006)")
+	rootCmd.PersistentFlags().StringVar(&htmlTheme, "html-theme", "", "colors of -o html: light, dark or auto (follow the browser)")
 
 	// Location flags
 	rootCmd.PersistentFlags().StringVarP(&address, "address", "a", "", "city or address (e.g., \"Cairo, Egypt\")")
 	rootCmd.PersistentFlags().Float64Var(&latitude, "lat", 0, "latitude in decimal degrees")
 	rootCmd.PersistentFlags().Float64Var(&longitude, "lon", 0, "longitude in decimal degrees")
 	rootCmd.PersistentFlags().BoolVarP(&autoDetect, "auto", "A", false, "auto-detect location from IP")
+	rootCmd.PersistentFlags().StringVar(&mosqueName, "mosque", "", "use a saved mosque profile for location, Iqama and Jumu'ah")
 
 	// Calculation flags
 	rootCmd.PersistentFlags().IntVarP(&method, "method", "m", 0, "calculation method ID (default: 5)")
 
 	// Display flags
-	rootCmd.PersistentFlags().StringVarP(&language, "lang", "l", "", "language: en or ar")
+	rootCmd.PersistentFlags().StringVarP(&language, "lang", "l", "", "language code (see 'pray languages')")
+	rootCmd.PersistentFlags().StringVar(&numerals, "numerals", "", "numerals: latin or native (e.g., Eastern Arabic digits)")
+	rootCmd.PersistentFlags().StringVar(&timeFormat, "time-format", "", "time format: 24h, 12h or locale")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", "", "output theme: auto, emoji, unicode, ascii or plain (screen readers)")
 	rootCmd.PersistentFlags().BoolVar(&showQibla, "qibla", false, "include Qibla direction")
 	rootCmd.PersistentFlags().BoolVar(&showDua, "dua", false, "include daily Du'a")
 	rootCmd.PersistentFlags().StringVar(&hijriFormat, "hijri", "", "Hijri date display: title/desc/both/none")
@@ -154,6 +237,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&travelerMode, "traveler", false, "enable travel/Qasr mode")
 	rootCmd.PersistentFlags().BoolVar(&jumuahMode, "jumuah", false, "add Jumu'ah (Friday) prayer")
 	rootCmd.PersistentFlags().BoolVar(&ramadanMode, "ramadan", false, "enable Ramadan mode")
+	rootCmd.PersistentFlags().BoolVar(&showIqama, "iqama", false, "show Iqama (congregation) times")
 
 	// Config management flags
 	rootCmd.PersistentFlags().BoolVar(&saveConfig, "save", false, "save current flags as default config")
@@ -244,6 +328,48 @@ func GetLanguage() string {
 	return GetConfig().Language
 }
 
+// UseNativeDigits returns whether numbers should use the language's native digits
+func UseNativeDigits() bool {
+	if numerals != "" {
+		return numerals == "native"
+	}
+	return GetConfig().Output.Numerals == "native"
+}
+
+// GetTimeFormat returns the time format flag or config value
+func GetTimeFormat() string {
+	if timeFormat != "" {
+		return timeFormat
+	}
+	return GetConfig().Output.TimeFormat
+}
+
+// GetTheme returns the output theme for the theme flag or config value,
+// detecting one from the terminal for "auto"
+func GetTheme() *output.Theme {
+	name := themeName
+	if name == "" {
+		name = GetConfig().Output.Theme
+	}
+	if th := output.GetTheme(name, GetConfig().Output.NoEmoji); th != nil {
+		return th
+	}
+	return output.DetectTheme(GetConfig().Output.NoEmoji)
+}
+
+// printHeading prints a command heading with the theme's icon and a rule of the given width
+func printHeading(th *output.Theme, icon, title string, width int) {
+	fmt.Printf("%s%s\n", th.Prefix(icon), title)
+	if rule := th.Rule(width); rule != "" {
+		fmt.Println(rule)
+	}
+}
+
+// newLocalizer returns a Localizer for the language, numerals and time format in effect
+func newLocalizer() *output.Localizer {
+	return output.NewLocalizer(GetLanguage(), UseNativeDigits()).WithTimeFormat(GetTimeFormat())
+}
+
 // ShouldShowQibla returns whether to show Qibla direction
 func ShouldShowQibla() bool {
 	return showQibla || GetConfig().Features.Qibla
@@ -264,7 +390,38 @@ func GetHijriFormat() string {
 
 // IsTravelerMode returns whether traveler mode is enabled
 func IsTravelerMode() bool {
-	return travelerMode || GetConfig().Features.TravelerMode
+	return travelerMode || autoTraveler || GetConfig().Features.TravelerMode
+}
+
+// checkTravel enables traveler mode when the detected location is beyond the travel threshold
+// from home, recording when the journey began. It returns a notice to show, or "" when at home.
+func checkTravel(current *location.Location) string {
+	cfg := GetConfig()
+	if !cfg.Travel.Auto {
+		return ""
+	}
+
+	away, distance := cfg.IsAway(current)
+	if !away {
+		if err := config.ClearTravelState(); err != nil && IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		return ""
+	}
+
+	state, err := config.LoadTravelState()
+	if err != nil && IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if state == nil {
+		state = &config.TravelState{Since: time.Now(), Location: current.GetDisplayAddress()}
+		if err := state.Save(); err != nil && IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	autoTraveler = true
+	return fmt.Sprintf("Traveling %.0f km from home (day %d): traveler mode enabled", distance, state.DaysAway(time.Now()))
 }
 
 // IsJumuahMode returns whether Jumu'ah mode is enabled
@@ -277,6 +434,53 @@ func IsRamadanMode() bool {
 	return ramadanMode || GetConfig().Ramadan.Enabled
 }
 
+// IsIqamaEnabled returns whether Iqama times should be shown
+func IsIqamaEnabled() bool {
+	return showIqama || GetConfig().Iqama.Enabled
+}
+
+// GetActiveMosque returns the mosque profile selected by --mosque or the config, or nil if none
+func GetActiveMosque() (*config.MosqueConfig, error) {
+	name := mosqueName
+	if name == "" {
+		name = GetConfig().Mosque
+	}
+	if name == "" {
+		return nil, nil
+	}
+	m := GetConfig().GetMosque(name)
+	if m == nil {
+		return nil, fmt.Errorf("unknown mosque: %s (run 'pray mosque list' to see saved mosques)", name)
+	}
+	return m, nil
+}
+
+// GetIqamaRules returns the Iqama rules for the given date, or nil when Iqama is disabled.
+// A mosque profile always enables Iqama and takes precedence over the config.
+func GetIqamaRules(date time.Time, m *config.MosqueConfig) *prayer.IqamaRules {
+	iqama := GetConfig().Iqama
+	offsets, fixed, round := iqama.Offsets, iqama.Fixed, iqama.Round
+
+	if m != nil {
+		mosqueIqama := m.IqamaFor(date.Weekday())
+		if mosqueIqama.Offsets != "" {
+			offsets = mosqueIqama.Offsets
+		}
+		fixed, round = mosqueIqama.Fixed, mosqueIqama.Round
+	} else if !IsIqamaEnabled() {
+		return nil
+	}
+
+	rules, err := prayer.NewIqamaRules(offsets, fixed, round)
+	if err != nil {
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring invalid iqama settings: %v\n", err)
+		}
+		return nil
+	}
+	return rules
+}
+
 // ShouldSaveConfig returns whether to save flags to config
 func ShouldSaveConfig() bool {
 	return saveConfig
@@ -292,6 +496,72 @@ func GetOutputFile() string {
 	return outputFile
 }
 
+// GetOutputFormat returns the output format: --output > --template/--template-file > config
+func GetOutputFormat() string {
+	if outputFormat != "" {
+		return outputFormat
+	}
+	if templateText != "" || templateFile != "" {
+		return "template"
+	}
+	return GetConfig().Output.Format
+}
+
+// GetDelimiter returns the CSV field delimiter: flag > config
+func GetDelimiter() string {
+	if delimiter != "" {
+		return delimiter
+	}
+	return GetConfig().Output.Delimiter
+}
+
+// GetDateFormat returns the Go layout of dates in CSV and TSV output: flag > config
+func GetDateFormat() string {
+	if dateFormat != "" {
+		return dateFormat
+	}
+	return GetConfig().Output.DateFormat
+}
+
+// GetHTMLTheme returns the color theme of HTML output: flag > config
+func GetHTMLTheme() string {
+	if htmlTheme != "" {
+		return htmlTheme
+	}
+	return GetConfig().Output.HTMLTheme
+}
+
+// printJSON prints data as a line of JSON in the versioned envelope shared by all
+// machine-readable output (see 'pray schema')
+func printJSON(data any) error {
+	return output.WriteNDJSON(os.Stdout, data)
+}
+
+// isJSONOutput reports whether the output format in effect, from the flag or the config,
+// is json or ndjson, which commands that print a single object treat alike
+func isJSONOutput() bool {
+	format := GetOutputFormat()
+	return format == "json" || format == "ndjson"
+}
+
+// GetTemplate returns the template for the template format: --template-file > --template > config
+func GetTemplate() (string, error) {
+	if templateFile != "" {
+		data, err := os.ReadFile(templateFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template: %w", err)
+		}
+		return string(data), nil
+	}
+	if templateText != "" {
+		return templateText, nil
+	}
+	if tmpl := GetConfig().Output.Template; tmpl != "" {
+		return tmpl, nil
+	}
+	return "", fmt.Errorf("the template format needs --template, --template-file or output.template")
+}
+
 // ensureConfigDir creates the config directory if it doesn't exist
 func ensureConfigDir() error {
 	configDir, err := config.GetConfigDir()
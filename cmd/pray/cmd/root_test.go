@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/config"
+)
+
+func TestIsJSONOutput(t *testing.T) {
+	saved, savedFormat := cfg, outputFormat
+	t.Cleanup(func() { cfg, outputFormat = saved, savedFormat })
+
+	tests := []struct {
+		flag   string
+		config string
+		want   bool
+	}{
+		{"", "table", false},
+		{"json", "table", true},
+		{"ndjson", "table", true},
+		// The config's format applies without the flag, and the flag overrides it
+		{"", "json", true},
+		{"", "ndjson", true},
+		{"pretty", "json", false},
+	}
+	for _, tt := range tests {
+		cfg = config.DefaultConfig()
+		cfg.Output.Format = tt.config
+		outputFormat = tt.flag
+		if got := isJSONOutput(); got != tt.want {
+			t.Errorf("isJSONOutput() with --output %q and output.format %q = %v, want %v", tt.flag, tt.config, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [json|webhook]",
+	Short: "Print the JSON Schema of machine-readable output",
+	Long: `Print the JSON Schema (draft 2020-12) of pray's machine-readable output.
+
+All JSON output (pray -o json, pray next -o json, pray wait -o json) and the
+webhook payload share a versioned envelope:
+
+  {"schemaVersion": 1, "generatedAt": "...", "source": {...}, "data": {...}}
+
+schemaVersion is bumped only when a field is removed, renamed or changes
+meaning, so scripts can check it before reading data. YAML and XML output
+use the same envelope.`,
+	Example: `  pray schema
+  pray schema webhook > webhook.schema.json`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: output.SchemaNames(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := "json"
+		if len(args) == 1 {
+			name = args[0]
+		}
+		schema, err := output.Schema(name)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(schema)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
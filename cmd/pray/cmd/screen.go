@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+)
+
+// Escape sequences for full-screen displays
+const (
+	enterScreen = "\033[?1049h\033[?25l" // Switch to the alternate screen and hide the cursor
+	leaveScreen = "\033[?25h\033[?1049l" // Show the cursor and return to the main screen
+)
+
+// drawScreen redraws the screen in place, overwriting the previous frame line by line
+// so the display does not flicker
+func drawScreen(lines []string) {
+	var b strings.Builder
+	b.WriteString("\033[H")
+	for i, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\033[K")
+		if i < len(lines)-1 {
+			b.WriteString("\r\n")
+		}
+	}
+	b.WriteString("\033[J")
+	fmt.Print(b.String())
+}
+
+// screenSize returns the terminal size, falling back to $COLUMNS and $LINES, then 80x24
+func screenSize() (width, height int) {
+	if width, height, ok := terminalSize(); ok {
+		return width, height
+	}
+	width, height = 80, 24
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		width = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("LINES")); err == nil && n > 0 {
+		height = n
+	}
+	return width, height
+}
+
+// centerLine centers text in width columns and paints it, cutting it to width when longer
+func centerLine(text string, width int, paint func(a ...interface{}) string) string {
+	n := output.DisplayWidth(text)
+	if n > width {
+		runes := []rune(text)
+		text = string(runes[:min(width, len(runes))])
+		n = width
+	}
+	return strings.Repeat(" ", max((width-n)/2, 0)) + paint(text)
+}
+
+// ansiPattern matches ANSI color sequences
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// centerBlock indents lines, which may be colored, by the same amount so that the widest
+// is centered in width columns and the lines stay aligned with each other
+func centerBlock(lines []string, width int) []string {
+	widest := 0
+	for _, line := range lines {
+		widest = max(widest, output.DisplayWidth(ansiPattern.ReplaceAllString(line, "")))
+	}
+	indent := strings.Repeat(" ", max((width-widest)/2, 0))
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = indent + line
+	}
+	return result
+}
+
+// truncate cuts text to width columns
+func truncate(text string, width int) string {
+	if output.DisplayWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:max(min(width, len(runes)), 0)])
+}
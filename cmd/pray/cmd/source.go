@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/api"
+	"github.com/AbdElrahmaN31/pray-cli/internal/cache"
+	"github.com/AbdElrahmaN31/pray-cli/internal/config"
+	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// errNoLocation is returned by resolveSource when no location is configured
+var errNoLocation = errors.New("no location configured. Run 'pray init' or 'pray config detect --save'")
+
+// prayerSource is where prayer times are fetched from: the location, method and
+// mosque profile resolved from flags and config
+type prayerSource struct {
+	client       *api.Client
+	params       *api.PrayerTimesParams
+	location     string
+	methodID     int
+	mosque       *config.MosqueConfig
+	detected     *location.Location // Set when the location was auto-detected
+	travelNotice string             // Set when auto-detection finds the user away from home
+	cached       *api.CachedClient  // Serves fetches from the response cache when set
+	cacheOnly    bool               // Never fetch from the network, see useCacheOnly
+}
+
+// timesFetcher fetches prayer times, directly or through the response cache
+type timesFetcher interface {
+	GetPrayerTimes(ctx context.Context, params *api.PrayerTimesParams) (*api.PrayerTimesResponse, error)
+	GetPrayerTimesByAddress(ctx context.Context, params *api.PrayerTimesParams) (*api.PrayerTimesResponse, error)
+}
+
+// resolveSource resolves the location with the same priority as the other commands:
+// flags > mosque profile > config. It returns errNoLocation when none is set.
+func resolveSource() (*prayerSource, error) {
+	cfg := GetConfig()
+
+	mosque, err := GetActiveMosque()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &prayerSource{mosque: mosque, methodID: cfg.Method}
+	if method != 0 {
+		s.methodID = method
+	}
+	s.client = api.NewClient(api.WithTimeout(time.Duration(cfg.APITimeout) * time.Second))
+	s.params = api.NewPrayerTimesParams().
+		WithMethod(s.methodID).
+		WithLanguage(GetLanguage())
+
+	var tz string
+	switch {
+	case autoDetect:
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		loc, err := location.NewDetector().DetectFromIP(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to auto-detect location: %w", err)
+		}
+		s.params.WithCoordinates(loc.Latitude, loc.Longitude)
+		s.location = loc.GetDisplayAddress()
+		s.detected = loc
+		s.travelNotice = checkTravel(loc)
+		tz = loc.Timezone
+	case address != "":
+		s.params.WithAddress(address)
+		s.location = address
+	case latitude != 0 || longitude != 0:
+		s.params.WithCoordinates(latitude, longitude)
+		s.location = fmt.Sprintf("%.4f, %.4f", latitude, longitude)
+	case mosque != nil:
+		s.params.WithCoordinates(mosque.Location.Latitude, mosque.Location.Longitude)
+		s.location = mosque.Name
+		tz = mosque.Location.Timezone
+	case cfg.IsConfigured():
+		s.params.WithCoordinates(cfg.Location.Latitude, cfg.Location.Longitude)
+		s.location = cfg.Location.GetDisplayAddress()
+		tz = cfg.Location.Timezone
+	default:
+		return nil, errNoLocation
+	}
+	if tz != "" {
+		s.params.WithTimezone(tz)
+	}
+
+	return s, nil
+}
+
+// useCache serves the source's fetches from the response cache, unless caching is
+// disabled in the config or bypassed with --no-cache
+func (s *prayerSource) useCache() {
+	opts := []api.CachedClientOption{api.WithBypassCache(ShouldBypassCache())}
+	if dir, err := config.GetCacheDir(); err == nil {
+		if c, err := cache.New(dir, cache.WithEnabled(GetConfig().CacheEnabled)); err == nil {
+			opts = append(opts, api.WithCache(c))
+		}
+	}
+	s.cached = api.NewCachedClient(s.client, opts...)
+}
+
+// useCacheOnly serves the source's fetches from the response cache only, so they do not
+// go to the network. Fetches of uncached dates fail with api.ErrNotCached, as do all
+// fetches when the cache is disabled or bypassed with --no-cache.
+func (s *prayerSource) useCacheOnly() {
+	opts := []api.CachedClientOption{api.WithBypassCache(ShouldBypassCache()), api.WithCacheOnly(true)}
+	if dir, err := config.GetCacheDir(); err == nil {
+		if c, err := cache.New(dir, cache.WithEnabled(GetConfig().CacheEnabled)); err == nil {
+			opts = append(opts, api.WithCache(c))
+		}
+	}
+	s.cached = api.NewCachedClient(s.client, opts...)
+	s.cacheOnly = true
+}
+
+// fetcher returns the response cache when the source uses it, or the API client
+func (s *prayerSource) fetcher() timesFetcher {
+	if s.cached != nil {
+		return s.cached
+	}
+	return s.client
+}
+
+// fetchResponse fetches the API response for date
+func (s *prayerSource) fetchResponse(date time.Time) (*api.PrayerTimesResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(GetConfig().APITimeout)*time.Second)
+	defer cancel()
+
+	params := *s.params
+	params.Date = date
+
+	fetcher := s.fetcher()
+
+	var resp *api.PrayerTimesResponse
+	var err error
+	if params.Address != "" {
+		resp, err = fetcher.GetPrayerTimesByAddress(ctx, &params)
+	} else {
+		resp, err = fetcher.GetPrayerTimes(ctx, &params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch prayer times: %w", err)
+	}
+	return resp, nil
+}
+
+// fetchDay fetches the prayer day of date, with Iqama, Jumu'ah and traveler settings applied
+func (s *prayerSource) fetchDay(date time.Time) (*prayer.Day, error) {
+	resp, err := s.fetchResponse(date)
+	if err != nil {
+		return nil, err
+	}
+
+	day := buildDay(resp, s.location, s.mosque)
+	day.Method = config.GetMethodName(s.methodID)
+	return day, nil
+}
+
+// prayerData prepares a response of date for the formatters with the display flags
+// and config applied: Iqama and Jumu'ah times, Friday reminders and the du'a
+func (s *prayerSource) prayerData(resp *api.PrayerTimesResponse, qibla *api.QiblaData, date time.Time) *output.PrayerData {
+	cfg := GetConfig()
+	hijri := GetHijriFormat()
+
+	data := &output.PrayerData{
+		Response:     resp,
+		Location:     s.location,
+		Method:       config.GetMethodName(s.methodID),
+		Qibla:        qibla,
+		ShowQibla:    ShouldShowQibla(),
+		ShowDua:      ShouldShowDua(),
+		ShowHijri:    hijri != "none",
+		HijriFormat:  hijri,
+		Language:     GetLanguage(),
+		NativeDigits: UseNativeDigits(),
+		TimeFormat:   GetTimeFormat(),
+		Theme:        GetTheme(),
+		Delimiter:    GetDelimiter(),
+		DateFormat:   GetDateFormat(),
+		HTMLTheme:    GetHTMLTheme(),
+		NoColor:      noColor,
+	}
+
+	// Mosque profile details
+	if s.mosque != nil {
+		data.Mosque = s.mosque.Name
+	}
+
+	// Normalized day shared by all formatters, with Jumu'ah replacing Dhuhr on Fridays
+	data.Day = buildDay(resp, s.location, s.mosque)
+	data.Day.Method = data.Method
+	if data.HasJumuah() {
+		data.JumuahDuration = cfg.Jumuah.Duration
+	}
+	if s.cacheOnly {
+		s.attachCachedFollowingDay(data.Day, time.Now())
+	} else {
+		params := *s.params
+		params.Date = date
+		attachFollowingDay(s.fetcher(), &params, data.Day, time.Now(), s.mosque)
+	}
+
+	if isFridayEnabled(s.mosque) {
+		data.Reminders = buildFridayReminders(data.Day, date)
+	}
+
+	// Du'a of the day
+	if data.ShowDua {
+		data.Dua = selectDua(data.Day, date)
+	}
+	return data
+}
+
+// attachCachedFollowingDay is attachFollowingDay for a cache-only source: the next day's
+// prayer times come from the cache, or are estimated from the day's own times.
+func (s *prayerSource) attachCachedFollowingDay(day *prayer.Day, now time.Time) {
+	if day.Following != nil || prayer.NextEvent(day.Events, now) != nil {
+		return
+	}
+	tomorrow := day.Tomorrow()
+	if prayer.NextEvent(tomorrow.Events, now) == nil {
+		return
+	}
+	if following, err := s.fetchDay(day.Date.AddDate(0, 0, 1)); err == nil {
+		day.Following = following
+	} else {
+		day.Following = tomorrow
+	}
+}
+
+// fetchToday fetches today's prayer day and the given number of following days.
+// The following days are estimated when they cannot be fetched.
+func (s *prayerSource) fetchToday(following int) (*prayer.Day, error) {
+	day, err := s.fetchDay(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if following > 0 {
+		_ = fetchFollowingDays(s.fetcher(), s.params, day, following, s.mosque)
+	}
+	return day, nil
+}
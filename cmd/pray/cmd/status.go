@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/api"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+)
+
+// errAutoDetectCacheOnly rejects --auto for output read from the cache only, as detecting
+// the location goes to the network
+var errAutoDetectCacheOnly = errors.New("--auto is not supported: cached prayer times only")
+
+// displayStatus prints the next prayer and its countdown for a status bar. Bars run it
+// every few seconds, so the prayer times come from the response cache only. With --auto
+// it prints nothing, which hides the module, rather than wait on the network.
+func displayStatus(format string) error {
+	data, err := cachedPrayerData()
+	if errors.Is(err, errAutoDetectCacheOnly) {
+		if IsVerbose() {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return output.GetFormatter(format).Format(os.Stdout, data)
+}
+
+// cachedPrayerData returns the prayer times from the response cache only, without any
+// network request: today's, or yesterday's as an estimate until today's are cached
+func cachedPrayerData() (*output.PrayerData, error) {
+	if autoDetect {
+		return nil, errAutoDetectCacheOnly
+	}
+	source, err := resolveSource()
+	if err != nil {
+		return nil, err
+	}
+	source.useCacheOnly()
+
+	now := time.Now()
+	date := now
+	resp, err := source.fetchResponse(date)
+	if errors.Is(err, api.ErrNotCached) {
+		date = now.AddDate(0, 0, -1)
+		resp, err = source.fetchResponse(date)
+	}
+	if errors.Is(err, api.ErrNotCached) {
+		return nil, errors.New("no cached prayer times for today: run 'pray' to fetch them")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := source.prayerData(resp, nil, date)
+	if !sameDay(date, now) {
+		data.Day = data.Day.Tomorrow()
+		source.attachCachedFollowingDay(data.Day, now)
+	}
+	return data, nil
+}
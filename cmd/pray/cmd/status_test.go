@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatusAutoDetect(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home+"/.config")
+	t.Setenv("XDG_CACHE_HOME", home+"/.cache")
+	t.Cleanup(func() {
+		autoDetect, outputFormat = false, ""
+		rootCmd.SetArgs(nil)
+	})
+
+	// --auto would detect the location over the network, so status bars get nothing
+	for _, format := range []string{"waybar", "i3bar", "polybar", "tmux"} {
+		t.Run(format, func(t *testing.T) {
+			autoDetect = false
+			rootCmd.SetArgs([]string{"--auto", "-o", format})
+
+			var err error
+			start := time.Now()
+			out := captureStdout(t, func() { err = rootCmd.Execute() })
+
+			if err != nil {
+				t.Errorf("pray --auto -o %s error = %v, want nil", format, err)
+			}
+			if out != "" {
+				t.Errorf("pray --auto -o %s printed %q, want nothing", format, out)
+			}
+			// Only catches an IP lookup, which would take seconds
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("pray --auto -o %s took %v, it should not detect the location", format, elapsed)
+			}
+		})
+	}
+}
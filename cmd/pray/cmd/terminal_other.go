@@ -0,0 +1,21 @@
+//go:build !unix
+
+package cmd
+
+import (
+	"errors"
+	"os"
+)
+
+// terminalSize is not supported on this platform; callers fall back to $COLUMNS and $LINES
+func terminalSize() (width, height int, ok bool) {
+	return 0, 0, false
+}
+
+// notifyResize is a no-op: without SIGWINCH, resizes are picked up on the next redraw
+func notifyResize(c chan<- os.Signal) {}
+
+// enterCbreak is not supported on this platform
+func enterCbreak() (restore func(), err error) {
+	return nil, errors.New("interactive mode needs a Unix terminal")
+}
@@ -2,16 +2,18 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/AbdElrahmaN31/pray-cli/internal/api"
 	"github.com/AbdElrahmaN31/pray-cli/internal/config"
-	"github.com/AbdElrahmaN31/pray-cli/internal/location"
 	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 var todayCmd = &cobra.Command{
@@ -33,44 +35,23 @@ func runTodayCommand(cmd *cobra.Command, args []string) error {
 func fetchAndDisplayPrayerTimes(cmd *cobra.Command, date time.Time) error {
 	cfg := GetConfig()
 
-	// Determine location
-	var lat, lon float64
-	var locationStr string
-	var tz string
-	var detectedLoc *location.Location
-
-	// Priority: flags > config
-	if autoDetect {
-		// Auto-detect location
-		detector := location.NewDetector()
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel()
+	format := GetOutputFormat()
+	if output.IsStatusFormat(format) {
+		cmd.SilenceUsage = true
+		return displayStatus(format)
+	}
+	var tmpl string
+	if format == "template" {
+		var err error
+		if tmpl, err = GetTemplate(); err != nil {
+			return err
+		}
+	}
 
-		loc, err := detector.DetectFromIP(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to auto-detect location: %w", err)
-		}
-		lat = loc.Latitude
-		lon = loc.Longitude
-		locationStr = loc.GetDisplayAddress()
-		tz = loc.Timezone
-		detectedLoc = loc
-	} else if address != "" {
-		// Use address from flag
-		locationStr = address
-	} else if latitude != 0 || longitude != 0 {
-		// Use coordinates from flags
-		lat = latitude
-		lon = longitude
-		locationStr = fmt.Sprintf("%.4f, %.4f", lat, lon)
-	} else if cfg.IsConfigured() {
-		// Use config
-		lat = cfg.Location.Latitude
-		lon = cfg.Location.Longitude
-		locationStr = cfg.Location.GetDisplayAddress()
-		tz = cfg.Location.Timezone
-	} else {
-		fmt.Println("👋 Welcome! No location configured.")
+	// Priority: flags > mosque profile > config
+	source, err := resolveSource()
+	if errors.Is(err, errNoLocation) {
+		fmt.Printf("%sWelcome! No location configured.\n", GetTheme().Prefix("wave"))
 		fmt.Println()
 		fmt.Println("Set your location using one of these options:")
 		fmt.Println("  pray config detect --save    Auto-detect from IP")
@@ -79,17 +60,17 @@ func fetchAndDisplayPrayerTimes(cmd *cobra.Command, date time.Time) error {
 		fmt.Println("  pray init                    Interactive setup")
 		return nil
 	}
-
-	// Determine method
-	methodID := cfg.Method
-	if method != 0 {
-		methodID = method
+	if err != nil {
+		return err
+	}
+	if source.travelNotice != "" && !IsQuiet() {
+		fmt.Fprintf(os.Stderr, "%s%s\n", GetTheme().Prefix("traveler"), source.travelNotice)
 	}
 
 	// Handle --save flag: save current settings to config
 	if ShouldSaveConfig() {
-		if detectedLoc != nil {
-			cfg.Location = *detectedLoc
+		if source.detected != nil {
+			cfg.Location = *source.detected
 		} else if address != "" {
 			cfg.Location.Address = address
 			cfg.Location.Source = "manual"
@@ -122,6 +103,9 @@ func fetchAndDisplayPrayerTimes(cmd *cobra.Command, date time.Time) error {
 		if ramadanMode {
 			cfg.Ramadan.Enabled = true
 		}
+		if showIqama {
+			cfg.Iqama.Enabled = true
+		}
 		if outputFormat != "" {
 			cfg.Output.Format = outputFormat
 		}
@@ -130,73 +114,32 @@ func fetchAndDisplayPrayerTimes(cmd *cobra.Command, date time.Time) error {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 		if !IsQuiet() {
-			fmt.Println("✓ Settings saved to config")
-		}
-	}
-
-	// Create API client
-	client := api.NewClient(api.WithTimeout(time.Duration(cfg.APITimeout) * time.Second))
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.APITimeout)*time.Second)
-	defer cancel()
-
-	// Build params
-	params := api.NewPrayerTimesParams().
-		WithDate(date).
-		WithMethod(methodID)
-
-	var resp *api.PrayerTimesResponse
-	var err error
-
-	if address != "" {
-		// Fetch by address
-		params.WithAddress(address)
-		resp, err = client.GetPrayerTimesByAddress(ctx, params)
-	} else {
-		// Fetch by coordinates
-		params.WithCoordinates(lat, lon)
-		if tz != "" {
-			params.WithTimezone(tz)
+			fmt.Printf("%sSettings saved to config\n", GetTheme().Prefix("ok"))
 		}
-		resp, err = client.GetPrayerTimes(ctx, params)
 	}
 
+	// Fetch through the response cache that status bar formats read
+	source.useCache()
+	resp, err := source.fetchResponse(date)
 	if err != nil {
-		return fmt.Errorf("failed to fetch prayer times: %w", err)
+		return err
 	}
 
 	// Get Qibla if enabled (use flag helpers)
 	var qibla *api.QiblaData
-	qiblaEnabled := ShouldShowQibla() || outputFormat == "json" || outputFormat == "webhook"
-	if qiblaEnabled && (lat != 0 && lon != 0) {
-		qiblaResp, err := client.GetQibla(ctx, lat, lon)
+	qiblaEnabled := ShouldShowQibla() || slices.Contains([]string{"json", "ndjson", "yaml", "xml", "webhook"}, GetOutputFormat())
+	if lat, lon := source.params.Latitude, source.params.Longitude; qiblaEnabled && (lat != 0 && lon != 0) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.APITimeout)*time.Second)
+		defer cancel()
+		qiblaResp, err := source.cached.GetQibla(ctx, lat, lon)
 		if err == nil {
 			qibla = &qiblaResp.Data
 		}
 	}
 
-	// Use flag helpers for display options
-	hijri := GetHijriFormat()
-	lang := GetLanguage()
-
 	// Prepare output data
-	data := &output.PrayerData{
-		Response:    resp,
-		Location:    locationStr,
-		Method:      config.GetMethodName(methodID),
-		Qibla:       qibla,
-		ShowQibla:   ShouldShowQibla(),
-		ShowDua:     ShouldShowDua(),
-		ShowHijri:   hijri != "none",
-		HijriFormat: hijri,
-		Language:    lang,
-		NoColor:     noColor,
-	}
-
-	// Determine output format
-	format := cfg.Output.Format
-	if outputFormat != "" {
-		format = outputFormat
-	}
+	data := source.prayerData(resp, qibla, date)
+	data.Template = tmpl
 
 	// Get formatter
 	formatter := output.GetFormatter(format)
@@ -215,7 +158,7 @@ func fetchAndDisplayPrayerTimes(cmd *cobra.Command, date time.Time) error {
 			return err
 		}
 		if !IsQuiet() {
-			fmt.Printf("✓ Output saved to: %s\n", outFile)
+			fmt.Printf("%sOutput saved to: %s\n", GetTheme().Prefix("ok"), outFile)
 		}
 		return nil
 	}
@@ -223,3 +166,125 @@ func fetchAndDisplayPrayerTimes(cmd *cobra.Command, date time.Time) error {
 	// Write to stdout
 	return formatter.Format(os.Stdout, data)
 }
+
+// buildDay builds the normalized prayer day of the response with Iqama and Jumu'ah times
+// and traveler mode applied
+func buildDay(resp *api.PrayerTimesResponse, location string, mosque *config.MosqueConfig) *prayer.Day {
+	day := output.NewDay(resp)
+	day.Location = location
+	day.SetIqama(buildIqamaTimes(resp.Data.Timings, day.Date, mosque))
+	day.SetJumuah(buildJumuah(resp.Data.Timings, day.Date, mosque))
+	day.Traveler = IsTravelerMode()
+	return day
+}
+
+// attachFollowingDay fetches the next day's prayer times once all of the day's prayers have
+// passed, so the next prayer rolls over to tomorrow's Fajr at its real time. When the fetch
+// fails, the estimate from the day's own times is kept so it is not retried.
+func attachFollowingDay(fetcher timesFetcher, params *api.PrayerTimesParams, day *prayer.Day, now time.Time, mosque *config.MosqueConfig) {
+	if day.Following != nil || prayer.NextEvent(day.Events, now) != nil {
+		return
+	}
+	tomorrow := day.Tomorrow()
+	if prayer.NextEvent(tomorrow.Events, now) == nil {
+		// Not today's times (e.g., a past date), so there is nothing to roll over to
+		return
+	}
+	if err := fetchFollowingDays(fetcher, params, day, 1, mosque); err != nil {
+		day.Following = tomorrow
+	}
+}
+
+// fetchFollowingDays fetches the prayer times of the given number of days after day and
+// chains them as each day's Following. Each day gets its own API timeout. It stops at
+// the first failure, leaving the remaining days estimated.
+func fetchFollowingDays(fetcher timesFetcher, params *api.PrayerTimesParams, day *prayer.Day, days int, mosque *config.MosqueConfig) error {
+	for i := 0; i < days; i++ {
+		following := *params
+		following.Date = day.Date.AddDate(0, 0, 1)
+
+		resp, err := fetchFollowingDay(fetcher, &following)
+		if err != nil {
+			return err
+		}
+
+		day.Following = buildDay(resp, day.Location, mosque)
+		day.Following.Method = day.Method
+		day = day.Following
+	}
+	return nil
+}
+
+// fetchFollowingDay fetches the prayer times of params.Date within the API timeout
+func fetchFollowingDay(fetcher timesFetcher, params *api.PrayerTimesParams) (*api.PrayerTimesResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(GetConfig().APITimeout)*time.Second)
+	defer cancel()
+
+	if params.Address != "" {
+		return fetcher.GetPrayerTimesByAddress(ctx, params)
+	}
+	return fetcher.GetPrayerTimes(ctx, params)
+}
+
+// buildIqamaTimes computes Iqama times from the API timings with the mosque's or the config's
+// rules, or nil when Iqama is disabled
+func buildIqamaTimes(timings api.Timings, date time.Time, mosque *config.MosqueConfig) map[string]string {
+	rules := GetIqamaRules(date, mosque)
+	if rules == nil {
+		return nil
+	}
+	return rules.Times(output.AdhanTimes(timings))
+}
+
+// isFridayEnabled reports whether Jumu'ah times and Friday reminders should be shown
+func isFridayEnabled(mosque *config.MosqueConfig) bool {
+	return IsJumuahMode() || (mosque != nil && len(mosque.Jumuah) > 0)
+}
+
+// buildJumuah returns the Jumu'ah khutbah times for the date, or nil when it is not a Friday.
+// Mosque times take precedence, then the configured times, then the Dhuhr time.
+func buildJumuah(timings api.Timings, date time.Time, mosque *config.MosqueConfig) []string {
+	if date.Weekday() != time.Friday || !isFridayEnabled(mosque) {
+		return nil
+	}
+	if mosque != nil && len(mosque.Jumuah) > 0 {
+		return mosque.Jumuah
+	}
+	times, err := prayer.ParseJumuahTimes(GetConfig().Jumuah.Times)
+	if err != nil || len(times) == 0 {
+		return []string{cleanTime(timings.Dhuhr)}
+	}
+	return times
+}
+
+// buildFridayReminders returns the Friday reminders for the date.
+// Today's reminders depend on the current time, other dates use the start of the day.
+func buildFridayReminders(day *prayer.Day, date time.Time) []string {
+	now := time.Now().In(day.Date.Location())
+	if y, m, d := date.Date(); y != now.Year() || m != now.Month() || d != now.Day() {
+		now = day.Date
+	}
+
+	maghrib := day.Event("Maghrib")
+	if maghrib == nil {
+		return nil
+	}
+	var khutbah time.Time
+	if jumuah := day.Event(prayer.JumuahName); jumuah != nil {
+		khutbah = jumuah.Time
+	}
+	return prayer.FridayReminders(now, maghrib.Time, khutbah)
+}
+
+// selectDua picks the du'a for the date. For today, morning or evening adhkar are
+// chosen from the upcoming prayer; other dates get a daily du'a.
+func selectDua(day *prayer.Day, date time.Time) *prayer.Dua {
+	now := time.Now().In(day.Date.Location())
+	next := ""
+	if y, m, d := date.Date(); y == now.Year() && m == now.Month() && d == now.Day() {
+		if e := day.Next(now); e != nil {
+			next = e.Name
+		}
+	}
+	return prayer.SelectDua(date, next)
+}
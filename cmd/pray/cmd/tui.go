@@ -0,0 +1,761 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/api"
+	"github.com/AbdElrahmaN31/pray-cli/internal/config"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+)
+
+// Panes of the dashboard
+const (
+	paneToday = iota
+	paneWeek
+	paneMonth
+	paneQibla
+	paneSettings
+)
+
+var paneNames = []string{"Today", "Week", "Month", "Qibla", "Settings"}
+
+// tuiFetchers is the number of days fetched at once when filling the week and month panes
+const tuiFetchers = 6
+
+var tuiCmd = &cobra.Command{
+	Use:     "tui",
+	Aliases: []string{"dashboard"},
+	Short:   "Interactive full-screen dashboard",
+	Long: `Open an interactive dashboard with panes for today, the week, the month, the
+Qibla compass and the current settings.
+
+Keys:
+  1-5, Tab      Switch pane
+  ←/→           Previous or next day
+  ↑/↓           Previous or next week
+  PgUp/PgDn     Previous or next month
+  t             Back to today
+  m / M         Next or previous calculation method, to compare times live
+  l / L         Next or previous saved place: the config location, home and mosques
+  r             Reload
+  q, Esc        Quit
+
+Prayer times are served from the response cache where possible, so moving between
+days and switching back to a method or place does not fetch them again.`,
+	RunE: runTUICommand,
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+// dashboard is the state of a running pray tui
+type dashboard struct {
+	places  []*prayerSource
+	place   int
+	methods []config.CalculationMethod
+	method  int // Index into methods
+	pane    int
+	date    time.Time // Selected day, at noon so adding days is not affected by DST
+
+	mu        sync.Mutex
+	responses map[string]*api.PrayerTimesResponse // By place, method and date
+	failed    map[string]bool                     // Days and Qibla that failed to fetch, until reloaded
+	qibla     map[int]*api.QiblaData              // By place
+	status    string                              // Last error
+
+	loading bool              // A load is running in the background
+	loaded  chan struct{}     // Signals the end of a load, so the loop draws again
+	offline *api.CachedClient // Cache-only client for drawing, which must not block
+
+	theme *output.Theme
+	l     *output.Localizer
+}
+
+func runTUICommand(cmd *cobra.Command, args []string) error {
+	if !isatty.IsTerminal(os.Stdin.Fd()) || !isatty.IsTerminal(os.Stdout.Fd()) {
+		return errors.New("pray tui needs an interactive terminal")
+	}
+
+	source, err := resolveSource()
+	if err != nil {
+		return err
+	}
+	source.useCache()
+
+	if noColor {
+		color.NoColor = true
+	}
+
+	d := &dashboard{
+		places:    savedPlaces(source),
+		responses: make(map[string]*api.PrayerTimesResponse),
+		failed:    make(map[string]bool),
+		qibla:     make(map[int]*api.QiblaData),
+		loaded:    make(chan struct{}, 1),
+		theme:     GetTheme(),
+		l:         newLocalizer(),
+	}
+	for _, m := range config.CalculationMethods {
+		// Custom needs its own parameters, so it is only listed when already in use
+		if m.ID != 23 || m.ID == source.methodID {
+			d.methods = append(d.methods, m)
+		}
+		if m.ID == source.methodID {
+			d.method = len(d.methods) - 1
+		}
+	}
+	d.today()
+
+	offline := *source
+	offline.useCacheOnly()
+	d.offline = offline.cached
+
+	restoreTerminal, err := enterCbreak()
+	if err != nil {
+		return err
+	}
+	defer restoreTerminal()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	resizeChan := make(chan os.Signal, 1)
+	notifyResize(resizeChan)
+	defer signal.Stop(resizeChan)
+
+	keys := make(chan string)
+	go readKeys(keys)
+
+	// Redraw regularly so the next prayer and its countdown stay current
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	fmt.Print(enterScreen)
+	defer fmt.Print(leaveScreen)
+
+	d.draw()
+	for {
+		select {
+		case <-sigChan:
+			return nil
+		case <-resizeChan:
+		case <-ticker.C:
+		case <-d.loaded:
+			d.loading = false
+		case key, ok := <-keys:
+			if !ok || !d.handleKey(key) {
+				return nil
+			}
+		}
+		d.draw()
+	}
+}
+
+// savedPlaces returns the places the dashboard can switch between: the resolved source
+// first, then the config location, home and mosque profiles that differ from it
+func savedPlaces(base *prayerSource) []*prayerSource {
+	cfg := GetConfig()
+	places := []*prayerSource{base}
+
+	add := func(name string, lat, lon float64, tz string, mosque *config.MosqueConfig) {
+		for _, p := range places {
+			if p.params.Address == "" && p.params.Latitude == lat && p.params.Longitude == lon && p.mosque == mosque {
+				return
+			}
+		}
+		params := *base.params
+		params.Address = ""
+		params.Timezone = tz
+		params.WithCoordinates(lat, lon)
+		places = append(places, &prayerSource{
+			client:   base.client,
+			cached:   base.cached,
+			params:   &params,
+			location: name,
+			methodID: base.methodID,
+			mosque:   mosque,
+		})
+	}
+
+	if cfg.IsConfigured() {
+		add(cfg.Location.GetDisplayAddress(), cfg.Location.Latitude, cfg.Location.Longitude, cfg.Location.Timezone, nil)
+	}
+	if cfg.Home.Latitude != 0 || cfg.Home.Longitude != 0 {
+		add("Home", cfg.Home.Latitude, cfg.Home.Longitude, cfg.Home.Timezone, nil)
+	}
+	for i := range cfg.Mosques {
+		m := &cfg.Mosques[i]
+		add(m.Name, m.Location.Latitude, m.Location.Longitude, m.Location.Timezone, m)
+	}
+	return places
+}
+
+// handleKey applies a key press, returning false to quit
+func (d *dashboard) handleKey(key string) bool {
+	switch key {
+	case "q", "Q", "esc":
+		return false
+	case "1", "2", "3", "4", "5":
+		d.pane = int(key[0] - '1')
+	case "tab":
+		d.pane = (d.pane + 1) % len(paneNames)
+	case "backtab":
+		d.pane = (d.pane + len(paneNames) - 1) % len(paneNames)
+	case "left":
+		d.date = d.date.AddDate(0, 0, -1)
+	case "right":
+		d.date = d.date.AddDate(0, 0, 1)
+	case "up":
+		d.date = d.date.AddDate(0, 0, -7)
+	case "down":
+		d.date = d.date.AddDate(0, 0, 7)
+	case "pgup":
+		d.date = d.date.AddDate(0, -1, 0)
+	case "pgdn":
+		d.date = d.date.AddDate(0, 1, 0)
+	case "t":
+		d.today()
+	case "m":
+		d.setMethod(d.method + 1)
+	case "M":
+		d.setMethod(d.method - 1)
+	case "l":
+		d.place = (d.place + 1) % len(d.places)
+	case "L":
+		d.place = (d.place + len(d.places) - 1) % len(d.places)
+	case "r":
+		d.mu.Lock()
+		d.responses = make(map[string]*api.PrayerTimesResponse)
+		d.failed = make(map[string]bool)
+		d.qibla = make(map[int]*api.QiblaData)
+		d.mu.Unlock()
+	}
+	return true
+}
+
+// today selects the current day
+func (d *dashboard) today() {
+	now := time.Now()
+	d.date = time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.Local)
+}
+
+// setMethod switches every place to the method at index i, wrapping around
+func (d *dashboard) setMethod(i int) {
+	d.method = (i + len(d.methods)) % len(d.methods)
+	id := d.methods[d.method].ID
+	for _, p := range d.places {
+		p.methodID = id
+		p.params.WithMethod(id)
+	}
+}
+
+// source returns the selected place
+func (d *dashboard) source() *prayerSource {
+	return d.places[d.place]
+}
+
+// key identifies a day of the selected place and method
+func (d *dashboard) key(date time.Time) string {
+	return fmt.Sprintf("%d/%d/%s", d.place, d.source().methodID, date.Format("2006-01-02"))
+}
+
+// response returns the fetched response of a day of the selected place and method, or nil
+func (d *dashboard) response(date time.Time) *api.PrayerTimesResponse {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.responses[d.key(date)]
+}
+
+// dates returns the days shown by the current pane
+func (d *dashboard) dates() []time.Time {
+	switch d.pane {
+	case paneWeek:
+		// Weeks start on Monday
+		start := d.date.AddDate(0, 0, -((int(d.date.Weekday()) + 6) % 7))
+		dates := make([]time.Time, 7)
+		for i := range dates {
+			dates[i] = start.AddDate(0, 0, i)
+		}
+		return dates
+	case paneMonth:
+		var dates []time.Time
+		for day := d.date.AddDate(0, 0, 1-d.date.Day()); day.Month() == d.date.Month(); day = day.AddDate(0, 0, 1) {
+			dates = append(dates, day)
+		}
+		return dates
+	case paneToday, paneQibla:
+		return []time.Time{d.date}
+	}
+	return nil
+}
+
+// tuiLoad is what the current pane is missing, fetched in the background
+type tuiLoad struct {
+	src   prayerSource         // Copy of the selected place, unaffected by key presses
+	place int                  // Index of the place
+	days  map[string]time.Time // Missing days by key
+	qibla bool                 // The Qibla direction of the place is missing
+	date  string               // Key of the selected day
+}
+
+// qiblaKey identifies the Qibla direction of a place in failed
+func qiblaKey(place int) string {
+	return fmt.Sprintf("qibla/%d", place)
+}
+
+// pending returns what the current pane is missing, or nil when it has everything. The
+// today pane also needs the following day, for the next prayer after Isha.
+func (d *dashboard) pending() *tuiLoad {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	src := *d.source()
+	params := *src.params
+	src.params = &params
+	load := &tuiLoad{src: src, place: d.place, days: make(map[string]time.Time), date: d.key(d.date)}
+
+	dates := d.dates()
+	if d.pane == paneToday {
+		dates = append(dates, d.date.AddDate(0, 0, 1))
+	}
+	for _, date := range dates {
+		key := d.key(date)
+		if d.responses[key] == nil && !d.failed[key] {
+			load.days[key] = date
+		}
+	}
+	load.qibla = d.pane == paneQibla && d.qibla[d.place] == nil && !d.failed[qiblaKey(d.place)]
+
+	if len(load.days) == 0 && !load.qibla {
+		return nil
+	}
+	return load
+}
+
+// load fetches what a pane is missing, a few days at a time, and the Qibla direction for
+// the Qibla pane. Failures are shown in the status line.
+func (d *dashboard) load(load *tuiLoad) {
+	src := &load.src
+	sem := make(chan struct{}, tuiFetchers)
+	var wg sync.WaitGroup
+	for key, date := range load.days {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key string, date time.Time) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := src.fetchResponse(date)
+
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if err != nil {
+				d.failed[key] = true
+				d.status = err.Error()
+				return
+			}
+			d.responses[key] = resp
+		}(key, date)
+	}
+	wg.Wait()
+
+	if load.qibla {
+		lat, lon := src.params.Latitude, src.params.Longitude
+		d.mu.Lock()
+		resp := d.responses[load.date]
+		d.mu.Unlock()
+		if src.params.Address != "" && resp != nil {
+			lat, lon = resp.Data.Meta.Latitude, resp.Data.Meta.Longitude
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(GetConfig().APITimeout)*time.Second)
+		defer cancel()
+		qibla, err := src.cached.GetQibla(ctx, lat, lon)
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if err != nil {
+			d.failed[qiblaKey(load.place)] = true
+			d.status = err.Error()
+			return
+		}
+		d.qibla[load.place] = &qibla.Data
+	}
+}
+
+// draw renders the current pane. Missing data is loaded in the background, showing a
+// loading frame until the loop draws again when it has arrived.
+func (d *dashboard) draw() {
+	width, height := screenSize()
+	load := d.pending()
+	if load != nil && !d.loading {
+		d.loading = true
+		d.mu.Lock()
+		d.status = ""
+		d.mu.Unlock()
+		go func() {
+			d.load(load)
+			d.loaded <- struct{}{}
+		}()
+	}
+	drawScreen(d.render(width, height, load != nil))
+}
+
+// render lays out a frame: the pane tabs, the place, method and date, the pane and
+// the key help or the last error
+func (d *dashboard) render(width, height int, loading bool) []string {
+	th, l := d.theme, d.l
+	src := d.source()
+
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+	selected := color.New(color.FgCyan, color.Bold, color.ReverseVideo).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	dim := color.New(color.Faint).SprintFunc()
+
+	var tabs strings.Builder
+	for i, name := range paneNames {
+		tab := fmt.Sprintf(" %d %s ", i+1, name)
+		if i == d.pane {
+			tabs.WriteString(selected(tab))
+		} else {
+			tabs.WriteString(dim(tab))
+		}
+	}
+
+	heading := fmt.Sprintf("%s%s  %s  %s", th.Prefix("location"), src.location,
+		d.methods[d.method].Name, l.DateOf(d.date))
+	if len(d.places) > 1 {
+		heading = fmt.Sprintf("[%d/%d] %s", d.place+1, len(d.places), heading)
+	}
+
+	rule := th.Rule(width)
+	lines := []string{tabs.String(), cyan(truncate(heading, width)), rule}
+
+	contentHeight := max(height-len(lines)-2, 1)
+	var content []string
+	switch {
+	case loading:
+		content = []string{"", centerLine("Loading...", width, dim)}
+	case d.pane == paneToday:
+		content = d.renderToday(width)
+	case d.pane == paneWeek, d.pane == paneMonth:
+		content = d.renderTable(width, contentHeight)
+	case d.pane == paneQibla:
+		content = d.renderQibla(width, contentHeight)
+	case d.pane == paneSettings:
+		content = d.renderSettings(width)
+	}
+	if len(content) > contentHeight {
+		content = content[:contentHeight]
+	}
+	lines = append(lines, content...)
+	for len(lines) < height-2 {
+		lines = append(lines, "")
+	}
+
+	help := "←/→ day  ↑/↓ week  PgUp/PgDn month  t today  m/M method  l/L place  Tab pane  q quit"
+	footer := dim(truncate(help, width))
+	d.mu.Lock()
+	status := d.status
+	d.mu.Unlock()
+	if status != "" {
+		footer = red(truncate(th.Prefix("error")+status, width))
+	}
+	return append(lines, rule, footer)
+}
+
+// renderToday renders the selected day with the table or pretty formatter
+func (d *dashboard) renderToday(width int) []string {
+	resp := d.response(d.date)
+	if resp == nil {
+		return []string{"", centerLine("Prayer times are not available", width, fmt.Sprint)}
+	}
+
+	// The following day comes from the cache, where load put it, or is estimated
+	src := *d.source()
+	src.cached, src.cacheOnly = d.offline, true
+	data := src.prayerData(resp, nil, d.date)
+	data.ShowQibla = false
+
+	format := GetOutputFormat()
+	var formatter output.Formatter = &output.TableFormatter{}
+	if format == "pretty" {
+		formatter = &output.PrettyFormatter{}
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, data); err != nil {
+		return []string{err.Error()}
+	}
+	return centerBlock(strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"), width)
+}
+
+// renderTable renders the days of the week or month pane, one row of adhan times each,
+// scrolled to keep the selected day in view
+func (d *dashboard) renderTable(width, height int) []string {
+	l := d.l
+	cyan := color.New(color.FgCyan, color.Bold).SprintFunc()
+	selected := color.New(color.Bold, color.ReverseVideo).SprintFunc()
+	yellow := color.New(color.FgYellow, color.Bold).SprintFunc()
+	dim := color.New(color.Faint).SprintFunc()
+
+	names := []string{"Fajr", "Sunrise", "Dhuhr", "Asr", "Maghrib", "Isha"}
+	header := []string{"", ""}
+	for _, name := range names {
+		header = append(header, l.Prayer(name))
+	}
+
+	dates := d.dates()
+	rows := make([][]string, len(dates))
+	for i, date := range dates {
+		row := []string{l.Digits(date.Format("Mon 2 Jan")), ""}
+		resp := d.response(date)
+		if resp != nil {
+			row[1] = l.HijriDate(output.NewDay(resp).Hijri)
+		}
+		for _, name := range names {
+			value := "--:--"
+			if resp != nil {
+				value = l.Clock(timingOf(resp.Data.Timings, name))
+			}
+			row = append(row, value)
+		}
+		rows[i] = row
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], output.DisplayWidth(cell))
+		}
+	}
+	format := func(row []string) string {
+		var b strings.Builder
+		for i, cell := range row {
+			if i > 0 {
+				b.WriteString("  ")
+			}
+			b.WriteString(cell + strings.Repeat(" ", widths[i]-output.DisplayWidth(cell)))
+		}
+		return b.String()
+	}
+
+	// Drop the Hijri column when the table does not fit
+	if output.DisplayWidth(format(header)) > width {
+		widths[1] = 0
+		header[1] = ""
+		for _, row := range rows {
+			row[1] = ""
+		}
+	}
+
+	now := time.Now()
+	var lines []string
+	for i, row := range rows {
+		text := format(row)
+		switch {
+		case sameDay(dates[i], d.date):
+			text = selected(text)
+		case sameDay(dates[i], now):
+			text = yellow(text)
+		case dates[i].Before(now):
+			text = dim(text)
+		}
+		lines = append(lines, text)
+	}
+
+	// Scroll so the selected day stays visible below the header
+	if visible := height - 2; len(lines) > visible && visible > 0 {
+		first := 0
+		for i, date := range dates {
+			if sameDay(date, d.date) {
+				first = min(max(i-visible/2, 0), len(lines)-visible)
+			}
+		}
+		lines = lines[first : first+visible]
+	}
+
+	indent := strings.Repeat(" ", max((width-output.DisplayWidth(format(header)))/2, 0))
+	result := []string{"", indent + cyan(format(header))}
+	for _, line := range lines {
+		result = append(result, indent+line)
+	}
+	return result
+}
+
+// renderQibla renders the Qibla compass of the selected place, as large as fits
+func (d *dashboard) renderQibla(width, height int) []string {
+	th, l := d.theme, d.l
+	d.mu.Lock()
+	qibla := d.qibla[d.place]
+	d.mu.Unlock()
+	if qibla == nil {
+		return []string{"", centerLine("Qibla direction is not available", width, fmt.Sprint)}
+	}
+
+	lines := []string{""}
+	radius := min((height-5)/2, (width-4)/4, 12)
+	for _, row := range th.Compass(qibla.Direction, radius) {
+		lines = append(lines, centerLine(row, width, fmt.Sprint))
+	}
+	label := l.T("label.qiblaDirection", output.CompassDirection(qibla.Direction), qibla.Direction)
+	lines = append(lines, "", centerLine(th.Prefix("compass")+label, width, color.New(color.FgGreen).SprintFunc()))
+	return lines
+}
+
+// renderSettings lists the settings in use and the saved places
+func (d *dashboard) renderSettings(width int) []string {
+	th := d.theme
+	src := d.source()
+	cfg := GetConfig()
+
+	onOff := func(on bool) string {
+		if on {
+			return "on"
+		}
+		return "off"
+	}
+	coordinates := fmt.Sprintf("%.4f, %.4f", src.params.Latitude, src.params.Longitude)
+	if src.params.Address != "" {
+		coordinates = src.params.Address
+	}
+	cache := onOff(cfg.CacheEnabled)
+	if ShouldBypassCache() {
+		cache = "bypassed (--no-cache)"
+	}
+
+	settings := [][2]string{
+		{"Location", src.location},
+		{"Coordinates", coordinates},
+		{"Method", fmt.Sprintf("%s (%d)", d.methods[d.method].Name, src.methodID)},
+		{"Language", GetLanguage()},
+		{"Time format", GetTimeFormat()},
+		{"Theme", th.Name},
+		{"Iqama", onOff(src.mosque != nil || IsIqamaEnabled())},
+		{"Traveler mode", onOff(IsTravelerMode())},
+		{"Cache", cache},
+	}
+
+	var block []string
+	for _, s := range settings {
+		block = append(block, fmt.Sprintf("%-14s %s", s[0], s[1]))
+	}
+	block = append(block, "", "Saved places (l/L to switch)")
+	for i, p := range d.places {
+		marker := "  "
+		if i == d.place {
+			marker = "> "
+			if icon := th.Icon("next"); icon != "" {
+				marker = icon + " "
+			}
+		}
+		block = append(block, marker+p.location)
+	}
+	block = append(block, "", "Change settings with 'pray config set' or 'pray mosque'.")
+
+	return append([]string{""}, centerBlock(block, width)...)
+}
+
+// timingOf returns the adhan time of a prayer from the API timings
+func timingOf(t api.Timings, name string) string {
+	switch name {
+	case "Fajr":
+		return t.Fajr
+	case "Sunrise":
+		return t.Sunrise
+	case "Dhuhr":
+		return t.Dhuhr
+	case "Asr":
+		return t.Asr
+	case "Maghrib":
+		return t.Maghrib
+	case "Isha":
+		return t.Isha
+	}
+	return ""
+}
+
+// sameDay reports whether a and b fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// readKeys reads key presses from stdin and sends their names to keys until stdin closes
+func readKeys(keys chan<- string) {
+	buf := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// parseKeys splits terminal input into key names: arrows ("left", "up", ...), "pgup",
+// "pgdn", "tab", "backtab" and "esc", or the typed character. Unknown escape sequences
+// are skipped.
+func parseKeys(b []byte) []string {
+	var keys []string
+	for len(b) > 0 {
+		switch {
+		case b[0] == 0x1b && len(b) > 2 && (b[1] == '[' || b[1] == 'O'):
+			// CSI or SS3 sequence: parameters, then a final byte
+			i := 2
+			for i < len(b) && (b[i] >= '0' && b[i] <= '9' || b[i] == ';') {
+				i++
+			}
+			if i == len(b) {
+				return keys
+			}
+			params, final := string(b[2:i]), b[i]
+			b = b[i+1:]
+			switch {
+			case final == 'A':
+				keys = append(keys, "up")
+			case final == 'B':
+				keys = append(keys, "down")
+			case final == 'C':
+				keys = append(keys, "right")
+			case final == 'D':
+				keys = append(keys, "left")
+			case final == 'Z':
+				keys = append(keys, "backtab")
+			case final == '~' && params == "5":
+				keys = append(keys, "pgup")
+			case final == '~' && params == "6":
+				keys = append(keys, "pgdn")
+			}
+		case b[0] == 0x1b:
+			keys = append(keys, "esc")
+			b = b[1:]
+		case b[0] == '\t':
+			keys = append(keys, "tab")
+			b = b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, string(r))
+			b = b[size:]
+		}
+	}
+	return keys
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// Exit codes of pray wait when interrupted, following the shell's 128+signal convention
+const (
+	exitInterrupted = 130 // SIGINT (Ctrl+C)
+	exitTerminated  = 143 // SIGTERM
+)
+
+// waitCheckInterval is how often the wall clock is re-checked while waiting. Timers
+// do not advance while the system is suspended, so long sleeps are split up.
+const waitCheckInterval = 30 * time.Second
+
+var (
+	waitUntil  string
+	waitBefore time.Duration
+	nextWait   bool
+)
+
+var waitCmd = &cobra.Command{
+	Use:     "wait",
+	Aliases: []string{"wait-until"},
+	Short:   "Block until the next or a named prayer",
+	Long: `Sleep until the next prayer, or the next time of a named prayer, then exit.
+
+Prints nothing on wake, or the prayer as JSON with -o json, so it can be chained:
+
+  pray wait --until next && play-adhan
+  pray wait --until maghrib --before 10m && notify-send "Maghrib in 10 minutes"
+
+In traveler mode, next is the combined prayer (e.g., "Dhuhr + Asr" at Dhuhr time), as
+'pray next' shows it, while a named prayer such as asr is waited for at its own time.
+
+The wall clock is re-checked regularly, so waking after a system suspend is on time
+(or immediate if the prayer passed while suspended).
+
+Exit codes: 0 when the prayer time is reached, 1 on errors, 130 when interrupted
+with Ctrl+C and 143 when terminated.`,
+	RunE: runWaitCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(waitCmd)
+
+	waitCmd.Flags().StringVar(&waitUntil, "until", "next", "prayer to wait for: next, fajr, sunrise, dhuhr, asr, maghrib, isha or jumuah")
+	waitCmd.Flags().DurationVar(&waitBefore, "before", 0, "wake this long before the prayer (e.g., 10m)")
+
+	nextCmd.Flags().BoolVar(&nextWait, "wait", false, "block until the next prayer (same as 'pray wait')")
+	nextCmd.Flags().DurationVar(&waitBefore, "before", 0, "with --wait, wake this long before the prayer (e.g., 10m)")
+}
+
+func runWaitCommand(cmd *cobra.Command, args []string) error {
+	query := strings.ToLower(strings.ReplaceAll(waitUntil, "'", ""))
+	if query != "next" && !isPrayerQuery(query) {
+		return fmt.Errorf("invalid --until %q: use next, fajr, sunrise, dhuhr, asr, maghrib, isha or jumuah", waitUntil)
+	}
+	if waitBefore < 0 {
+		return fmt.Errorf("--before must not be negative")
+	}
+
+	source, err := resolveSource()
+	if err != nil {
+		return err
+	}
+	source.useCache()
+
+	// Jumu'ah may be up to a week away
+	following := 1
+	if query == "jumuah" {
+		following = 7
+	}
+	day, err := source.fetchToday(following)
+	if err != nil {
+		return err
+	}
+
+	event, ok := findWaitTarget(day, time.Now(), query, waitBefore)
+	if !ok {
+		if query == "jumuah" {
+			return fmt.Errorf("no upcoming Jumu'ah in the next week; enable it with --jumuah or a mosque profile")
+		}
+		return fmt.Errorf("no upcoming %s in the next week", waitUntil)
+	}
+	wake := event.Time.Add(-waitBefore)
+
+	if IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Waiting for %s at %s (waking at %s)\n",
+			event.Name, event.Time.Format("2006-01-02 15:04"), wake.Format("15:04:05"))
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	// event.Time has no monotonic reading, so time.Until measures wall-clock time
+	for remaining := time.Until(wake); remaining > 0; remaining = time.Until(wake) {
+		timer := time.NewTimer(min(remaining, waitCheckInterval))
+		select {
+		case <-timer.C:
+		case sig := <-sigChan:
+			timer.Stop()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			if sig == syscall.SIGTERM {
+				return &ExitError{Code: exitTerminated}
+			}
+			return &ExitError{Code: exitInterrupted}
+		}
+	}
+
+	if isJSONOutput() {
+		out := &output.WaitOutput{
+			Name: event.Name,
+			Time: event.Clock(),
+			Date: event.Time.Format("2006-01-02"),
+			ISO:  event.Time.Format(time.RFC3339),
+		}
+		if event.HasIqama() {
+			out.Iqama = event.Iqama.Format("15:04")
+		}
+		if waitBefore > 0 {
+			out.Before = waitBefore.String()
+		}
+		return printJSON(out)
+	}
+
+	return nil
+}
+
+// findWaitTarget returns the first upcoming event matching query whose wake time,
+// before the event, is still ahead of now. In traveler mode, next is the combined
+// prayer (e.g., "Dhuhr + Asr"), as pray next shows it, while a named prayer is
+// waited for at its own time.
+func findWaitTarget(day *prayer.Day, now time.Time, query string, before time.Duration) (prayer.Event, bool) {
+	if query == "next" {
+		for _, e := range day.Upcoming(now, 7*len(prayer.EventNames)) {
+			if !e.Time.Add(-before).Before(now) {
+				return e, true
+			}
+		}
+		return prayer.Event{}, false
+	}
+
+	// A week of days covers every prayer, including Jumu'ah
+	for i, d := 0, day; i <= 7 && len(d.Events) > 0; i, d = i+1, d.Tomorrow() {
+		for _, e := range d.Events {
+			if matchesPrayer(e.Name, query) && !e.Time.Add(-before).Before(now) {
+				return e, true
+			}
+		}
+	}
+	return prayer.Event{}, false
+}
+
+// isPrayerQuery reports whether query names a prayer that can be waited for
+func isPrayerQuery(query string) bool {
+	if query == "jumuah" {
+		return true
+	}
+	for _, name := range prayer.EventNames {
+		if name != "Midnight" && strings.ToLower(name) == query {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPrayer reports whether an event name matches a lower-case prayer query,
+// including Jumu'ah slots
+func matchesPrayer(name, query string) bool {
+	if strings.HasPrefix(name, prayer.JumuahName) {
+		name = "jumuah"
+	}
+	return strings.ToLower(name) == query
+}
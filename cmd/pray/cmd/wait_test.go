@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+func TestFindWaitTargetTraveler(t *testing.T) {
+	loc := time.FixedZone("EET", 2*60*60)
+	day := prayer.NewDay(time.Date(2026, 1, 9, 0, 0, 0, 0, loc), map[string]string{
+		"Fajr": "05:15", "Sunrise": "06:40", "Dhuhr": "12:09", "Asr": "15:12",
+		"Maghrib": "17:34", "Isha": "18:54", "Midnight": "00:09",
+	})
+	day.Traveler = true
+	now := time.Date(2026, 1, 9, 11, 0, 0, 0, loc)
+
+	tests := []struct {
+		query string
+		name  string
+		clock string
+	}{
+		// next is the combined prayer, as pray next shows it
+		{"next", "Dhuhr + Asr", "12:09"},
+		// A named prayer is waited for at its own time, not at the combined event
+		{"asr", "Asr", "15:12"},
+		{"isha", "Isha", "18:54"},
+		{"dhuhr", "Dhuhr", "12:09"},
+	}
+	for _, tt := range tests {
+		e, ok := findWaitTarget(day, now, tt.query, 0)
+		if !ok || e.Name != tt.name || e.Clock() != tt.clock {
+			t.Errorf("findWaitTarget(%q) = %s at %s, %v; want %s at %s", tt.query, e.Name, e.Clock(), ok, tt.name, tt.clock)
+		}
+	}
+
+	// Past today's Asr, it is tomorrow's
+	e, ok := findWaitTarget(day, time.Date(2026, 1, 9, 16, 0, 0, 0, loc), "asr", 0)
+	if !ok || e.Name != "Asr" || e.Time.Day() != 10 {
+		t.Errorf("findWaitTarget(asr) after Asr = %+v, %v; want tomorrow's Asr", e, ok)
+	}
+}
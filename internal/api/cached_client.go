@@ -4,16 +4,21 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/AbdElrahmaN31/pray-cli/internal/cache"
 )
 
+// ErrNotCached is returned by a cache-only CachedClient when the prayer times are not cached
+var ErrNotCached = errors.New("prayer times are not cached")
+
 // CachedClient wraps Client with caching support
 type CachedClient struct {
 	*Client
-	cache  *cache.Cache
-	bypass bool
+	cache     *cache.Cache
+	bypass    bool
+	cacheOnly bool
 }
 
 // CachedClientOption configures the CachedClient
@@ -33,6 +38,15 @@ func WithBypassCache(bypass bool) CachedClientOption {
 	}
 }
 
+// WithCacheOnly makes prayer times and Qibla come from the cache only, including expired
+// prayer times, without going to the network. Misses return ErrNotCached, as do all
+// fetches when the cache is not set, disabled or bypassed.
+func WithCacheOnly(cacheOnly bool) CachedClientOption {
+	return func(cc *CachedClient) {
+		cc.cacheOnly = cacheOnly
+	}
+}
+
 // NewCachedClient creates a new CachedClient
 func NewCachedClient(client *Client, opts ...CachedClientOption) *CachedClient {
 	cc := &CachedClient{
@@ -49,7 +63,10 @@ func NewCachedClient(client *Client, opts ...CachedClientOption) *CachedClient {
 
 // GetPrayerTimes fetches prayer times with caching support
 func (cc *CachedClient) GetPrayerTimes(ctx context.Context, params *PrayerTimesParams) (*PrayerTimesResponse, error) {
-	if cc.cache == nil || cc.bypass || !cc.cache.IsEnabled() {
+	if !cc.cacheUsable() {
+		if cc.cacheOnly {
+			return nil, ErrNotCached
+		}
 		return cc.Client.GetPrayerTimes(ctx, params)
 	}
 
@@ -60,14 +77,16 @@ func (cc *CachedClient) GetPrayerTimes(ctx context.Context, params *PrayerTimesP
 		params.Longitude,
 		params.GetDateString(),
 		params.Method,
+		params.Timezone,
+		params.Language,
 	)
 
 	// Try to get from cache
-	if data, found := cc.cache.Get(key); found {
-		var result PrayerTimesResponse
-		if err := json.Unmarshal(data, &result); err == nil {
-			return &result, nil
-		}
+	if result, found := cc.cachedTimes(key); found {
+		return result, nil
+	}
+	if cc.cacheOnly {
+		return nil, ErrNotCached
 	}
 
 	// Fetch from API
@@ -86,7 +105,10 @@ func (cc *CachedClient) GetPrayerTimes(ctx context.Context, params *PrayerTimesP
 
 // GetPrayerTimesByAddress fetches prayer times by address with caching support
 func (cc *CachedClient) GetPrayerTimesByAddress(ctx context.Context, params *PrayerTimesParams) (*PrayerTimesResponse, error) {
-	if cc.cache == nil || cc.bypass || !cc.cache.IsEnabled() {
+	if !cc.cacheUsable() {
+		if cc.cacheOnly {
+			return nil, ErrNotCached
+		}
 		return cc.Client.GetPrayerTimesByAddress(ctx, params)
 	}
 
@@ -96,14 +118,16 @@ func (cc *CachedClient) GetPrayerTimesByAddress(ctx context.Context, params *Pra
 		params.Address,
 		params.GetDateString(),
 		params.Method,
+		params.Timezone,
+		params.Language,
 	)
 
 	// Try to get from cache
-	if data, found := cc.cache.Get(key); found {
-		var result PrayerTimesResponse
-		if err := json.Unmarshal(data, &result); err == nil {
-			return &result, nil
-		}
+	if result, found := cc.cachedTimes(key); found {
+		return result, nil
+	}
+	if cc.cacheOnly {
+		return nil, ErrNotCached
 	}
 
 	// Fetch from API
@@ -120,9 +144,36 @@ func (cc *CachedClient) GetPrayerTimesByAddress(ctx context.Context, params *Pra
 	return result, nil
 }
 
+// cacheUsable reports whether fetches can be served from the cache: it is set, enabled
+// and not bypassed. A cache-only client returns ErrNotCached for every fetch otherwise.
+func (cc *CachedClient) cacheUsable() bool {
+	return cc.cache != nil && !cc.bypass && cc.cache.IsEnabled()
+}
+
+// cachedTimes returns the cached prayer times of a key. In cache-only mode, expired
+// entries are used too, as the times of a date do not change.
+func (cc *CachedClient) cachedTimes(key string) (*PrayerTimesResponse, bool) {
+	get := cc.cache.Get
+	if cc.cacheOnly {
+		get = cc.cache.Peek
+	}
+	data, found := get(key)
+	if !found {
+		return nil, false
+	}
+	var result PrayerTimesResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, false
+	}
+	return &result, true
+}
+
 // GetQibla fetches the Qibla direction with caching support
 func (cc *CachedClient) GetQibla(ctx context.Context, latitude, longitude float64) (*QiblaResponse, error) {
-	if cc.cache == nil || cc.bypass || !cc.cache.IsEnabled() {
+	if !cc.cacheUsable() {
+		if cc.cacheOnly {
+			return nil, ErrNotCached
+		}
 		return cc.Client.GetQibla(ctx, latitude, longitude)
 	}
 
@@ -140,6 +191,9 @@ func (cc *CachedClient) GetQibla(ctx context.Context, latitude, longitude float6
 			return &result, nil
 		}
 	}
+	if cc.cacheOnly {
+		return nil, ErrNotCached
+	}
 
 	// Fetch from API
 	result, err := cc.Client.GetQibla(ctx, latitude, longitude)
@@ -2,8 +2,14 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/cache"
 )
 
 func TestNewClient(t *testing.T) {
@@ -289,3 +295,153 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestCachedClientCacheOnly(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"code":200,"status":"OK","data":{"timings":{"Fajr":"05:15"}}}`)
+	}))
+	defer server.Close()
+
+	c, err := cache.New(t.TempDir(), cache.WithTTL(-time.Minute)) // Entries expire at once
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(WithBaseURL(server.URL), WithMaxRetries(0))
+	params := NewPrayerTimesParams().WithCoordinates(30, 31).WithMethod(5)
+	params.Date = time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+
+	// A normal fetch fills the cache
+	if _, err := NewCachedClient(client, WithCache(c)).GetPrayerTimes(context.Background(), params); err != nil {
+		t.Fatalf("GetPrayerTimes() error = %v", err)
+	}
+
+	// Cache-only reads the expired entry without a request
+	cacheOnly := NewCachedClient(client, WithCache(c), WithCacheOnly(true))
+	resp, err := cacheOnly.GetPrayerTimes(context.Background(), params)
+	if err != nil || resp.Data.Timings.Fajr != "05:15" {
+		t.Fatalf("cache-only GetPrayerTimes() = %v, %v", resp, err)
+	}
+
+	// and does not fetch what is not cached
+	params.Date = params.Date.AddDate(0, 0, 1)
+	if _, err := cacheOnly.GetPrayerTimes(context.Background(), params); !errors.Is(err, ErrNotCached) {
+		t.Errorf("cache-only GetPrayerTimes() of an uncached date error = %v, want ErrNotCached", err)
+	}
+	if requests != 1 {
+		t.Errorf("server got %d requests, want 1", requests)
+	}
+}
+
+func TestCachedClientCacheOnlyWithoutCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"code":200,"status":"OK","data":{"timings":{"Fajr":"05:15"}}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithMaxRetries(0))
+	params := NewPrayerTimesParams().WithCoordinates(30, 31).WithMethod(5)
+	params.Date = time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+
+	// Fill the cache, so only the cache settings decide
+	c, err := cache.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCachedClient(client, WithCache(c)).GetPrayerTimes(context.Background(), params); err != nil {
+		t.Fatalf("GetPrayerTimes() error = %v", err)
+	}
+	disabled, err := cache.New(t.TempDir(), cache.WithEnabled(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts []CachedClientOption
+	}{
+		{"no cache", nil},
+		{"disabled cache", []CachedClientOption{WithCache(disabled)}},
+		{"bypassed cache", []CachedClientOption{WithCache(c), WithBypassCache(true)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			cc := NewCachedClient(client, append(tt.opts, WithCacheOnly(true))...)
+			if _, err := cc.GetPrayerTimes(context.Background(), params); !errors.Is(err, ErrNotCached) {
+				t.Errorf("GetPrayerTimes() error = %v, want ErrNotCached", err)
+			}
+			byAddress := NewPrayerTimesParams().WithAddress("Cairo").WithMethod(5)
+			if _, err := cc.GetPrayerTimesByAddress(context.Background(), byAddress); !errors.Is(err, ErrNotCached) {
+				t.Errorf("GetPrayerTimesByAddress() error = %v, want ErrNotCached", err)
+			}
+			if _, err := cc.GetQibla(context.Background(), 30, 31); !errors.Is(err, ErrNotCached) {
+				t.Errorf("GetQibla() error = %v, want ErrNotCached", err)
+			}
+			if requests != 0 {
+				t.Errorf("server got %d requests, want 0", requests)
+			}
+		})
+	}
+}
+
+func TestCachedClientKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":200,"status":"OK","data":{"timings":{"Fajr":"05:15"}}}`)
+	}))
+	defer server.Close()
+
+	c, err := cache.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(WithBaseURL(server.URL), WithMaxRetries(0))
+	date := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	byCoordinates := func() *PrayerTimesParams {
+		params := NewPrayerTimesParams().WithCoordinates(30, 31).WithMethod(5).WithTimezone("Africa/Cairo").WithLanguage("en")
+		params.Date = date
+		return params
+	}
+	byAddress := func() *PrayerTimesParams {
+		params := NewPrayerTimesParams().WithAddress("Cairo").WithMethod(5).WithTimezone("Africa/Cairo").WithLanguage("en")
+		params.Date = date
+		return params
+	}
+
+	// Fill the cache in one timezone and language
+	online := NewCachedClient(client, WithCache(c))
+	if _, err := online.GetPrayerTimes(context.Background(), byCoordinates()); err != nil {
+		t.Fatalf("GetPrayerTimes() error = %v", err)
+	}
+	if _, err := online.GetPrayerTimesByAddress(context.Background(), byAddress()); err != nil {
+		t.Fatalf("GetPrayerTimesByAddress() error = %v", err)
+	}
+
+	offline := NewCachedClient(client, WithCache(c), WithCacheOnly(true))
+	tests := []struct {
+		name   string
+		change func(*PrayerTimesParams)
+		cached bool
+	}{
+		{"same params", func(*PrayerTimesParams) {}, true},
+		{"other timezone", func(p *PrayerTimesParams) { p.WithTimezone("Europe/London") }, false},
+		{"other language", func(p *PrayerTimesParams) { p.WithLanguage("ar") }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := byCoordinates()
+			tt.change(params)
+			if _, err := offline.GetPrayerTimes(context.Background(), params); (err == nil) != tt.cached {
+				t.Errorf("GetPrayerTimes() error = %v, cached should be %v", err, tt.cached)
+			}
+			params = byAddress()
+			tt.change(params)
+			if _, err := offline.GetPrayerTimesByAddress(context.Background(), params); (err == nil) != tt.cached {
+				t.Errorf("GetPrayerTimesByAddress() error = %v, cached should be %v", err, tt.cached)
+			}
+		})
+	}
+}
@@ -90,6 +90,11 @@ func (p *PrayerTimesParams) ToQueryParams() url.Values {
 		query.Set("iso8601", "true")
 	}
 
+	// Language, for localized fields where the API provides them
+	if p.Language != "" && p.Language != "en" {
+		query.Set("lang", p.Language)
+	}
+
 	return query
 }
 
@@ -196,6 +201,12 @@ func (p *PrayerTimesParams) WithTimezone(tz string) *PrayerTimesParams {
 	return p
 }
 
+// WithLanguage sets the language
+func (p *PrayerTimesParams) WithLanguage(lang string) *PrayerTimesParams {
+	p.Language = lang
+	return p
+}
+
 // CalendarParams builder methods
 
 // WithCoordinates sets latitude and longitude
@@ -107,6 +107,25 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 	return entry.Data, true
 }
 
+// Peek retrieves a cached entry even if it has expired, for data that does not go stale,
+// such as the prayer times of a given date. Expired entries are kept.
+func (c *Cache) Peek(key string) ([]byte, bool) {
+	if !c.enabled {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.getPath(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	return entry.Data, true
+}
+
 // Set stores data in the cache
 func (c *Cache) Set(key string, data []byte) error {
 	if !c.enabled {
@@ -12,6 +12,7 @@ import (
 type Config struct {
 	// Location settings
 	Location location.Location `yaml:"location"`
+	Home     location.Location `yaml:"home,omitempty"` // Home location for travel detection (default: location)
 
 	// Calculation settings
 	Method   int    `yaml:"method"`   // Calculation method ID (default: 5)
@@ -35,6 +36,22 @@ type Config struct {
 	// Iqama settings
 	Iqama IqamaConfig `yaml:"iqama"`
 
+	// Travel detection settings
+	Travel TravelConfig `yaml:"travel"`
+
+	// Countdown alerts
+	Countdown CountdownConfig `yaml:"countdown"`
+
+	// Kiosk display
+	Kiosk KioskConfig `yaml:"kiosk"`
+
+	// Shell prompt segment
+	Prompt PromptConfig `yaml:"prompt"`
+
+	// Mosque profiles
+	Mosques []MosqueConfig `yaml:"mosques,omitempty"`
+	Mosque  string         `yaml:"mosque,omitempty"` // Name of the active mosque profile
+
 	// Advanced settings
 	CacheEnabled bool `yaml:"cache_enabled"`
 	UpdateCheck  bool `yaml:"update_check"`
@@ -43,9 +60,16 @@ type Config struct {
 
 // OutputConfig contains display/output preferences
 type OutputConfig struct {
-	Format       string `yaml:"format"` // "table", "pretty", "json", "slack", "discord"
+	Format       string `yaml:"format"` // "table", "pretty", "json", "slack", "discord", "webhook", "template", "csv", "tsv", "markdown", "html", "yaml", "xml", "ndjson", "waybar", "i3bar", "polybar", "tmux"
 	ColorEnabled bool   `yaml:"color_enabled"`
-	NoEmoji      bool   `yaml:"no_emoji"`
+	NoEmoji      bool   `yaml:"no_emoji" mapstructure:"no_emoji"`
+	Numerals     string `yaml:"numerals"`                               // "latin" or "native" (e.g., Eastern Arabic digits)
+	TimeFormat   string `yaml:"time_format" mapstructure:"time_format"` // "24h", "12h" or "locale"
+	Theme        string `yaml:"theme"`                                  // "auto", "emoji", "unicode", "ascii" or "plain"
+	Template     string `yaml:"template,omitempty"`                     // Go text/template used by the template format
+	Delimiter    string `yaml:"delimiter"`                              // CSV field delimiter: a single character or "tab"
+	DateFormat   string `yaml:"date_format" mapstructure:"date_format"` // Go layout of dates in csv/tsv output
+	HTMLTheme    string `yaml:"html_theme" mapstructure:"html_theme"`   // HTML output colors: "light", "dark" or "auto"
 }
 
 // FeaturesConfig contains feature toggle settings
@@ -68,8 +92,9 @@ type CalendarConfig struct {
 
 // JumuahConfig contains Friday prayer settings
 type JumuahConfig struct {
-	Enabled  bool `yaml:"enabled"`
-	Duration int  `yaml:"duration"` // Duration in minutes
+	Enabled  bool   `yaml:"enabled"`
+	Duration int    `yaml:"duration"` // Duration in minutes
+	Times    string `yaml:"times"`    // Comma-separated khutbah times (empty = Dhuhr time)
 }
 
 // RamadanConfig contains Ramadan mode settings
@@ -84,6 +109,33 @@ type RamadanConfig struct {
 type IqamaConfig struct {
 	Enabled bool   `yaml:"enabled"`
 	Offsets string `yaml:"offsets"` // Comma-separated offsets for each prayer
+	Fixed   string `yaml:"fixed"`   // Fixed times per prayer (e.g., "Isha: 20:30")
+	Round   int    `yaml:"round"`   // Round iqama times to the nearest N minutes (0 = off)
+}
+
+// TravelConfig contains automatic traveler detection settings
+type TravelConfig struct {
+	Auto      bool    `yaml:"auto"`      // Enable traveler mode when an auto-detected location is far from home
+	Threshold float64 `yaml:"threshold"` // Distance from home in km that counts as travel
+}
+
+// CountdownConfig contains pray countdown alert settings
+type CountdownConfig struct {
+	Alerts  string `yaml:"alerts"`  // Comma-separated T-minus thresholds (e.g., "10m,5m,0")
+	Bell    bool   `yaml:"bell"`    // Ring the terminal bell at each threshold
+	Command string `yaml:"command"` // Shell command run at each threshold
+}
+
+// KioskConfig contains pray kiosk display settings
+type KioskConfig struct {
+	Announcements []string `yaml:"announcements,omitempty"` // Lines shown in rotation with the du'a
+	Rotate        int      `yaml:"rotate"`                  // Seconds each rotating line is shown (0 = default, 15)
+}
+
+// PromptConfig contains pray prompt settings
+type PromptConfig struct {
+	Format  string `yaml:"format"`  // Go template of the segment (empty = DefaultPromptFormat)
+	Timeout int    `yaml:"timeout"` // Milliseconds after which nothing is printed (0 = default, 100)
 }
 
 // DefaultConfig returns the default configuration
@@ -98,6 +150,12 @@ func DefaultConfig() *Config {
 			Format:       "table",
 			ColorEnabled: true,
 			NoEmoji:      false,
+			Numerals:     "latin",
+			TimeFormat:   "24h",
+			Theme:        "auto",
+			Delimiter:    ",",
+			DateFormat:   "2006-01-02",
+			HTMLTheme:    "light",
 		},
 		Features: FeaturesConfig{
 			Qibla:         false,
@@ -116,6 +174,7 @@ func DefaultConfig() *Config {
 		Jumuah: JumuahConfig{
 			Enabled:  false,
 			Duration: 60,
+			Times:    "",
 		},
 		Ramadan: RamadanConfig{
 			Enabled:          false,
@@ -126,6 +185,22 @@ func DefaultConfig() *Config {
 		Iqama: IqamaConfig{
 			Enabled: false,
 			Offsets: "15,0,10,10,5,10,0",
+			Fixed:   "",
+			Round:   0,
+		},
+		Travel: TravelConfig{
+			Auto:      true,
+			Threshold: 80,
+		},
+		Countdown: CountdownConfig{
+			Alerts: "10m,5m,0",
+		},
+		Kiosk: KioskConfig{
+			Rotate: 15,
+		},
+		Prompt: PromptConfig{
+			Format:  DefaultPromptFormat,
+			Timeout: 100,
 		},
 		CacheEnabled: true,
 		UpdateCheck:  true,
@@ -4,8 +4,17 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
 )
 
+func TestMain(m *testing.M) {
+	SetChoices(Choices{Languages: output.Languages(), Themes: output.ThemeNames(), HTMLThemes: output.HTMLThemes})
+	os.Exit(m.Run())
+}
+
 func TestDefaultConfig(t *testing.T) {
 	cfg := DefaultConfig()
 
@@ -60,6 +69,16 @@ func TestConfigValidation(t *testing.T) {
 			modify:  func(c *Config) { c.Language = "invalid" },
 			wantErr: true,
 		},
+		{
+			name:    "catalog language",
+			modify:  func(c *Config) { c.Language = "tr" },
+			wantErr: false,
+		},
+		{
+			name:    "invalid time format",
+			modify:  func(c *Config) { c.Output.TimeFormat = "13h" },
+			wantErr: true,
+		},
 		{
 			name:    "invalid output format",
 			modify:  func(c *Config) { c.Output.Format = "invalid" },
@@ -90,6 +109,66 @@ func TestConfigValidation(t *testing.T) {
 			modify:  func(c *Config) { c.APITimeout = 2 },
 			wantErr: true,
 		},
+		{
+			name:    "invalid iqama offsets",
+			modify:  func(c *Config) { c.Iqama.Offsets = "15,abc" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid iqama fixed time",
+			modify:  func(c *Config) { c.Iqama.Fixed = "Isha: 25:30" },
+			wantErr: true,
+		},
+		{
+			name:    "valid iqama fixed time",
+			modify:  func(c *Config) { c.Iqama.Fixed = "Isha: 20:30"; c.Iqama.Round = 5 },
+			wantErr: false,
+		},
+		{
+			name:    "invalid travel threshold",
+			modify:  func(c *Config) { c.Travel.Threshold = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid countdown alerts",
+			modify:  func(c *Config) { c.Countdown.Alerts = "10m,soon" },
+			wantErr: true,
+		},
+		{
+			name:    "valid countdown alerts",
+			modify:  func(c *Config) { c.Countdown.Alerts = "15, 5m, 30s" },
+			wantErr: false,
+		},
+		{
+			name:    "invalid output delimiter",
+			modify:  func(c *Config) { c.Output.Delimiter = ";;" },
+			wantErr: true,
+		},
+		{
+			name:    "tab output delimiter",
+			modify:  func(c *Config) { c.Output.Delimiter = "tab" },
+			wantErr: false,
+		},
+		{
+			name:    "invalid HTML theme",
+			modify:  func(c *Config) { c.Output.HTMLTheme = "sepia" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid kiosk rotate",
+			modify:  func(c *Config) { c.Kiosk.Rotate = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid prompt timeout",
+			modify:  func(c *Config) { c.Prompt.Timeout = 10000 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid home latitude",
+			modify:  func(c *Config) { c.Home.Latitude = 95; c.Home.Longitude = 31 },
+			wantErr: true,
+		},
 		{
 			name:    "invalid latitude",
 			modify:  func(c *Config) { c.Location.Latitude = 100 },
@@ -115,6 +194,16 @@ func TestConfigValidation(t *testing.T) {
 	}
 }
 
+func TestValidateDelimiter(t *testing.T) {
+	// The config accepts exactly the delimiters the CSV format can use
+	for _, d := range []string{"", ",", ";", "|", "tab", `\t`, "\t", "؛", ";;", `"`, "\n", "\r", "\xff"} {
+		_, parseErr := output.ParseDelimiter(d)
+		if err := ValidateDelimiter(d); (err == nil) != (parseErr == nil) {
+			t.Errorf("ValidateDelimiter(%q) = %v, output.ParseDelimiter() error = %v", d, err, parseErr)
+		}
+	}
+}
+
 func TestConfigSaveAndLoad(t *testing.T) {
 	// Create a temporary directory for the test
 	tmpDir, err := os.MkdirTemp("", "pray-test")
@@ -258,3 +347,160 @@ func TestValidateCoordinates(t *testing.T) {
 		}
 	}
 }
+
+func TestMosqueIqamaFor(t *testing.T) {
+	m := MosqueConfig{
+		Name: "Masjid Noor",
+		Iqama: MosqueIqama{
+			Offsets: "15,0,10,10,5,10,0",
+			Fixed:   "Isha: 20:30",
+		},
+		Weekly: map[string]MosqueIqama{
+			"friday": {Fixed: "Isha: 21:00"},
+			"sat":    {Offsets: "20,0,10,10,5,10,0", Round: 5},
+		},
+	}
+
+	monday := m.IqamaFor(time.Monday)
+	if monday.Fixed != "Isha: 20:30" || monday.Offsets != "15,0,10,10,5,10,0" {
+		t.Errorf("IqamaFor(Monday) = %+v, want defaults", monday)
+	}
+
+	friday := m.IqamaFor(time.Friday)
+	if friday.Fixed != "Isha: 20:30, Isha: 21:00" {
+		t.Errorf("IqamaFor(Friday).Fixed = %q, want weekday override appended", friday.Fixed)
+	}
+
+	saturday := m.IqamaFor(time.Saturday)
+	if saturday.Offsets != "20,0,10,10,5,10,0" || saturday.Round != 5 {
+		t.Errorf("IqamaFor(Saturday) = %+v, want overridden offsets and rounding", saturday)
+	}
+}
+
+func TestMosqueValidation(t *testing.T) {
+	valid := MosqueConfig{
+		Name:     "Masjid Noor",
+		Location: location.Location{Latitude: 30.05, Longitude: 31.24},
+		Jumuah:   []string{"13:00", "14:15"},
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{"valid mosque", func(c *Config) { c.Mosques = []MosqueConfig{valid}; c.Mosque = "masjid noor" }, false},
+		{"unknown active mosque", func(c *Config) { c.Mosque = "Missing" }, true},
+		{"missing location", func(c *Config) { c.Mosques = []MosqueConfig{{Name: "No Location"}} }, true},
+		{"invalid jumuah time", func(c *Config) {
+			m := valid
+			m.Jumuah = []string{"25:00"}
+			c.Mosques = []MosqueConfig{m}
+		}, true},
+		{"invalid weekday", func(c *Config) {
+			m := valid
+			m.Weekly = map[string]MosqueIqama{"someday": {Fixed: "Isha: 20:30"}}
+			c.Mosques = []MosqueConfig{m}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddAndRemoveMosque(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AddMosque(MosqueConfig{Name: "Masjid Noor", Jumuah: []string{"13:00"}})
+	cfg.AddMosque(MosqueConfig{Name: "masjid noor", Jumuah: []string{"13:30"}})
+
+	if len(cfg.Mosques) != 1 {
+		t.Fatalf("AddMosque() with same name should replace, got %d mosques", len(cfg.Mosques))
+	}
+	if cfg.GetMosque("MASJID NOOR").Jumuah[0] != "13:30" {
+		t.Error("AddMosque() did not replace existing profile")
+	}
+
+	cfg.Mosque = "Masjid Noor"
+	if !cfg.RemoveMosque("Masjid Noor") {
+		t.Fatal("RemoveMosque() returned false for existing mosque")
+	}
+	if cfg.Mosque != "" {
+		t.Error("RemoveMosque() should clear the active mosque")
+	}
+	if cfg.RemoveMosque("Masjid Noor") {
+		t.Error("RemoveMosque() returned true for missing mosque")
+	}
+}
+
+func TestIsAway(t *testing.T) {
+	cfg := DefaultConfig()
+	cairo := &location.Location{Latitude: 30.0444, Longitude: 31.2357}
+	giza := &location.Location{Latitude: 30.0131, Longitude: 31.2089}
+	alexandria := &location.Location{Latitude: 31.2001, Longitude: 29.9187}
+
+	// No home configured
+	if away, _ := cfg.IsAway(alexandria); away {
+		t.Error("IsAway() should be false without a home location")
+	}
+
+	// Falls back to the configured location
+	cfg.Location = *cairo
+	if away, _ := cfg.IsAway(giza); away {
+		t.Error("IsAway() should be false within the threshold")
+	}
+	away, distance := cfg.IsAway(alexandria)
+	if !away || distance < 170 || distance > 190 {
+		t.Errorf("IsAway() = %v, %.1f; want true, ~179", away, distance)
+	}
+
+	// An explicit home takes precedence
+	cfg.Home = *alexandria
+	if away, _ := cfg.IsAway(alexandria); away {
+		t.Error("IsAway() should use the home location over the configured location")
+	}
+}
+
+func TestTravelState(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	state, err := LoadTravelState()
+	if err != nil || state != nil {
+		t.Fatalf("LoadTravelState() = %v, %v; want nil, nil", state, err)
+	}
+
+	since := time.Date(2026, 2, 4, 22, 0, 0, 0, time.UTC)
+	if err := (&TravelState{Since: since, Location: "Alexandria"}).Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	state, err = LoadTravelState()
+	if err != nil || state == nil {
+		t.Fatalf("LoadTravelState() = %v, %v", state, err)
+	}
+	if state.Location != "Alexandria" {
+		t.Errorf("Location = %s, want Alexandria", state.Location)
+	}
+	if days := state.DaysAway(since.Add(3 * time.Hour)); days != 2 {
+		t.Errorf("DaysAway() = %d, want 2", days)
+	}
+	if days := state.DaysAway(since); days != 1 {
+		t.Errorf("DaysAway() = %d, want 1", days)
+	}
+
+	if err := ClearTravelState(); err != nil {
+		t.Fatalf("ClearTravelState() error = %v", err)
+	}
+	if state, _ := LoadTravelState(); state != nil {
+		t.Error("LoadTravelState() should return nil after clearing")
+	}
+}
@@ -68,12 +68,50 @@ var DefaultOutputFormats = []string{
 	"slack",
 	"discord",
 	"webhook",
+	"template",
+	"csv",
+	"tsv",
+	"markdown",
+	"html",
+	"yaml",
+	"xml",
+	"ndjson",
+	"waybar",
+	"i3bar",
+	"polybar",
+	"tmux",
 }
 
-// DefaultLanguages lists available languages
-var DefaultLanguages = []string{
-	"en",
-	"ar",
+// DefaultPromptFormat is the template of the pray prompt segment, e.g. "Asr 42m"
+const DefaultPromptFormat = "{{with .Next}}{{.Label}} {{short .Until}}{{end}}"
+
+// Choices lists the accepted values of settings whose options are defined by the output
+// package: its message catalogs and themes. Config does not import output, so the
+// commands set them with SetChoices before a config is validated.
+type Choices struct {
+	Languages  []string // Codes of the message catalogs
+	Themes     []string // Names of the output themes
+	HTMLThemes []string // Color themes of HTML output
+}
+
+var choices Choices
+
+// SetChoices sets the accepted values of the settings defined by the output package
+func SetChoices(c Choices) {
+	choices = c
+}
+
+// DefaultNumerals lists available numeral styles
+var DefaultNumerals = []string{
+	"latin",
+	"native",
+}
+
+// DefaultTimeFormats lists available clocks for displayed times
+var DefaultTimeFormats = []string{
+	"24h",
+	"12h",
+	"locale",
 }
 
 // PrayerNames contains the standard prayer names
@@ -0,0 +1,141 @@
+// Package config provides configuration management for the pray CLI
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// MosqueConfig describes a mosque profile with its own location and iqama schedule
+type MosqueConfig struct {
+	Name     string                 `yaml:"name"`
+	Location location.Location      `yaml:"location"`
+	Iqama    MosqueIqama            `yaml:"iqama"`            // Default iqama rules
+	Weekly   map[string]MosqueIqama `yaml:"weekly,omitempty"` // Per-weekday overrides (e.g., "friday")
+	Jumuah   []string               `yaml:"jumuah,omitempty"` // Jumu'ah khutbah times (HH:MM)
+}
+
+// MosqueIqama contains iqama rules for a mosque
+type MosqueIqama struct {
+	Offsets string `yaml:"offsets,omitempty"` // Comma-separated offsets for each prayer
+	Fixed   string `yaml:"fixed,omitempty"`   // Fixed times per prayer (e.g., "Isha: 20:30")
+	Round   int    `yaml:"round,omitempty"`   // Round iqama times to the nearest N minutes
+}
+
+// GetMosque returns the mosque profile with the given name (case-insensitive)
+func (c *Config) GetMosque(name string) *MosqueConfig {
+	for i := range c.Mosques {
+		if strings.EqualFold(c.Mosques[i].Name, name) {
+			return &c.Mosques[i]
+		}
+	}
+	return nil
+}
+
+// AddMosque adds a mosque profile, replacing any existing profile with the same name
+func (c *Config) AddMosque(m MosqueConfig) {
+	if existing := c.GetMosque(m.Name); existing != nil {
+		*existing = m
+		return
+	}
+	c.Mosques = append(c.Mosques, m)
+}
+
+// RemoveMosque removes a mosque profile by name, returning false if it was not found
+func (c *Config) RemoveMosque(name string) bool {
+	for i := range c.Mosques {
+		if strings.EqualFold(c.Mosques[i].Name, name) {
+			c.Mosques = append(c.Mosques[:i], c.Mosques[i+1:]...)
+			if strings.EqualFold(c.Mosque, name) {
+				c.Mosque = ""
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// IqamaFor returns the iqama rules for the given weekday, applying any weekly override
+func (m *MosqueConfig) IqamaFor(day time.Weekday) MosqueIqama {
+	rules := m.Iqama
+	var override MosqueIqama
+	found := false
+	for key, r := range m.Weekly {
+		if d, err := ParseWeekday(key); err == nil && d == day {
+			override, found = r, true
+			break
+		}
+	}
+	if !found {
+		return rules
+	}
+
+	if override.Offsets != "" {
+		rules.Offsets = override.Offsets
+	}
+	if override.Fixed != "" {
+		// Later entries win, so weekday fixed times override the defaults
+		if rules.Fixed != "" {
+			rules.Fixed = rules.Fixed + ", " + override.Fixed
+		} else {
+			rules.Fixed = override.Fixed
+		}
+	}
+	if override.Round > 0 {
+		rules.Round = override.Round
+	}
+	return rules
+}
+
+// ParseWeekday parses a weekday name or three-letter abbreviation (e.g., "fri", "Friday")
+func ParseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday: %s", s)
+}
+
+// Validate validates the mosque profile
+func (m *MosqueConfig) Validate() error {
+	return validateMosque(m)
+}
+
+// validateMosque validates a single mosque profile
+func validateMosque(m *MosqueConfig) error {
+	field := fmt.Sprintf("mosques[%s]", m.Name)
+
+	if strings.TrimSpace(m.Name) == "" {
+		return ValidationError{Field: "mosques", Message: "mosque name must not be empty"}
+	}
+
+	if !m.Location.IsValid() {
+		return ValidationError{Field: field + ".location", Message: "mosque requires valid latitude and longitude"}
+	}
+
+	if _, err := prayer.NewIqamaRules(m.Iqama.Offsets, m.Iqama.Fixed, m.Iqama.Round); err != nil {
+		return ValidationError{Field: field + ".iqama", Message: err.Error()}
+	}
+
+	for day, rules := range m.Weekly {
+		if _, err := ParseWeekday(day); err != nil {
+			return ValidationError{Field: field + ".weekly", Message: err.Error()}
+		}
+		if _, err := prayer.NewIqamaRules(rules.Offsets, rules.Fixed, rules.Round); err != nil {
+			return ValidationError{Field: field + ".weekly." + day, Message: err.Error()}
+		}
+	}
+
+	if _, err := prayer.ParseJumuahTimes(strings.Join(m.Jumuah, ",")); err != nil {
+		return ValidationError{Field: field + ".jumuah", Message: err.Error()}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+// Package config provides configuration management for the pray CLI
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+)
+
+// TravelState records an ongoing journey away from home
+type TravelState struct {
+	Since    time.Time `json:"since"`              // When the journey was first detected
+	Location string    `json:"location,omitempty"` // Location where the journey was first detected
+}
+
+// HomeLocation returns the home location used for travel detection, falling back to
+// the configured location. It returns nil when neither is set.
+func (c *Config) HomeLocation() *location.Location {
+	if c.Home.IsValid() {
+		return &c.Home
+	}
+	if c.Location.IsValid() {
+		return &c.Location
+	}
+	return nil
+}
+
+// IsAway reports whether a location is beyond the travel threshold from home,
+// along with the distance in km. It is always false when home is not set.
+func (c *Config) IsAway(current *location.Location) (bool, float64) {
+	home := c.HomeLocation()
+	if home == nil || current == nil || !current.IsValid() {
+		return false, 0
+	}
+	distance := home.DistanceTo(current)
+	return distance >= c.Travel.Threshold, distance
+}
+
+// DaysAway returns the number of days since the journey began, counting the first day as day 1
+func (s *TravelState) DaysAway(now time.Time) int {
+	sy, sm, sd := s.Since.In(now.Location()).Date()
+	ny, nm, nd := now.Date()
+	start := time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC)
+	today := time.Date(ny, nm, nd, 0, 0, 0, 0, time.UTC)
+	return int(today.Sub(start).Hours()/24) + 1
+}
+
+// GetTravelStatePath returns the full path to the travel state file
+func GetTravelStatePath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "travel.json"), nil
+}
+
+// LoadTravelState loads the travel state, returning nil when not traveling
+func LoadTravelState() (*TravelState, error) {
+	path, err := GetTravelStatePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get travel state path: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read travel state: %w", err)
+	}
+
+	var state TravelState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse travel state: %w", err)
+	}
+	return &state, nil
+}
+
+// Save saves the travel state
+func (s *TravelState) Save() error {
+	path, err := GetTravelStatePath()
+	if err != nil {
+		return fmt.Errorf("failed to get travel state path: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal travel state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write travel state: %w", err)
+	}
+	return nil
+}
+
+// ClearTravelState removes the travel state after returning home
+func ClearTravelState() error {
+	path, err := GetTravelStatePath()
+	if err != nil {
+		return fmt.Errorf("failed to get travel state path: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove travel state: %w", err)
+	}
+	return nil
+}
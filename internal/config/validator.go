@@ -4,6 +4,10 @@ package config
 import (
 	"fmt"
 	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 // ValidationError represents a configuration validation error
@@ -27,10 +31,10 @@ func ValidateConfig(cfg *Config) error {
 	}
 
 	// Validate language
-	if !slices.Contains(DefaultLanguages, cfg.Language) {
+	if !slices.Contains(choices.Languages, cfg.Language) {
 		return ValidationError{
 			Field:   "language",
-			Message: fmt.Sprintf("invalid language: %s (must be 'en' or 'ar')", cfg.Language),
+			Message: fmt.Sprintf("invalid language: %s (available: %s)", cfg.Language, strings.Join(choices.Languages, ", ")),
 		}
 	}
 
@@ -42,6 +46,46 @@ func ValidateConfig(cfg *Config) error {
 		}
 	}
 
+	// Validate numeral style
+	if !slices.Contains(DefaultNumerals, cfg.Output.Numerals) {
+		return ValidationError{
+			Field:   "output.numerals",
+			Message: fmt.Sprintf("invalid numerals: %s (must be 'latin' or 'native')", cfg.Output.Numerals),
+		}
+	}
+
+	// Validate time format
+	if !slices.Contains(DefaultTimeFormats, cfg.Output.TimeFormat) {
+		return ValidationError{
+			Field:   "output.time_format",
+			Message: fmt.Sprintf("invalid time format: %s (must be 24h, 12h, or locale)", cfg.Output.TimeFormat),
+		}
+	}
+
+	// Validate theme
+	if !slices.Contains(choices.Themes, cfg.Output.Theme) {
+		return ValidationError{
+			Field:   "output.theme",
+			Message: fmt.Sprintf("invalid theme: %s (available: %s)", cfg.Output.Theme, strings.Join(choices.Themes, ", ")),
+		}
+	}
+
+	// Validate CSV delimiter
+	if err := ValidateDelimiter(cfg.Output.Delimiter); err != nil {
+		return ValidationError{
+			Field:   "output.delimiter",
+			Message: err.Error(),
+		}
+	}
+
+	// Validate HTML theme
+	if !slices.Contains(choices.HTMLThemes, cfg.Output.HTMLTheme) {
+		return ValidationError{
+			Field:   "output.html_theme",
+			Message: fmt.Sprintf("invalid HTML theme: %s (available: %s)", cfg.Output.HTMLTheme, strings.Join(choices.HTMLThemes, ", ")),
+		}
+	}
+
 	// Validate Hijri display option
 	validHijriOptions := []string{"title", "desc", "both", "none"}
 	if !slices.Contains(validHijriOptions, cfg.Features.Hijri) {
@@ -66,6 +110,81 @@ func ValidateConfig(cfg *Config) error {
 		}
 	}
 
+	// Validate iqama settings
+	if _, err := prayer.NewIqamaRules(cfg.Iqama.Offsets, cfg.Iqama.Fixed, cfg.Iqama.Round); err != nil {
+		return ValidationError{
+			Field:   "iqama",
+			Message: err.Error(),
+		}
+	}
+
+	// Validate Jumu'ah settings
+	if _, err := prayer.ParseJumuahTimes(cfg.Jumuah.Times); err != nil {
+		return ValidationError{
+			Field:   "jumuah.times",
+			Message: err.Error(),
+		}
+	}
+	if cfg.Jumuah.Duration < 1 || cfg.Jumuah.Duration > 180 {
+		return ValidationError{
+			Field:   "jumuah.duration",
+			Message: "duration must be between 1 and 180 minutes",
+		}
+	}
+
+	// Validate travel detection settings
+	if cfg.Travel.Threshold < 1 || cfg.Travel.Threshold > 1000 {
+		return ValidationError{
+			Field:   "travel.threshold",
+			Message: "travel threshold must be between 1 and 1000 km",
+		}
+	}
+	if cfg.Home.Latitude != 0 || cfg.Home.Longitude != 0 {
+		if err := ValidateCoordinates(cfg.Home.Latitude, cfg.Home.Longitude); err != nil {
+			return ValidationError{
+				Field:   "home",
+				Message: err.Error(),
+			}
+		}
+	}
+
+	// Validate countdown alerts
+	if _, err := prayer.ParseThresholds(cfg.Countdown.Alerts); err != nil {
+		return ValidationError{
+			Field:   "countdown.alerts",
+			Message: err.Error(),
+		}
+	}
+
+	// Validate kiosk rotation
+	if cfg.Kiosk.Rotate < 0 || cfg.Kiosk.Rotate > 3600 {
+		return ValidationError{
+			Field:   "kiosk.rotate",
+			Message: "must be between 0 and 3600 seconds (0 for the default)",
+		}
+	}
+
+	// Validate prompt latency budget
+	if cfg.Prompt.Timeout < 0 || cfg.Prompt.Timeout > 5000 {
+		return ValidationError{
+			Field:   "prompt.timeout",
+			Message: "must be between 0 and 5000 milliseconds (0 for the default)",
+		}
+	}
+
+	// Validate mosque profiles
+	for i := range cfg.Mosques {
+		if err := validateMosque(&cfg.Mosques[i]); err != nil {
+			return err
+		}
+	}
+	if cfg.Mosque != "" && cfg.GetMosque(cfg.Mosque) == nil {
+		return ValidationError{
+			Field:   "mosque",
+			Message: fmt.Sprintf("unknown mosque: %s", cfg.Mosque),
+		}
+	}
+
 	// Validate API timeout
 	if cfg.APITimeout < 5 || cfg.APITimeout > 120 {
 		return ValidationError{
@@ -116,3 +235,17 @@ func ValidateCoordinates(lat, lon float64) error {
 	}
 	return ValidateLongitude(lon)
 }
+
+// ValidateDelimiter validates a CSV field delimiter: a single character, "tab" (or "\t")
+// or empty for a comma, as the CSV format reads it
+func ValidateDelimiter(s string) error {
+	switch s {
+	case "", "tab", `\t`:
+		return nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return fmt.Errorf("invalid delimiter %q: must be a single character or \"tab\"", s)
+	}
+	return nil
+}
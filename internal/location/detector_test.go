@@ -298,6 +298,28 @@ func TestValidateLocation(t *testing.T) {
 	}
 }
 
+func TestDistanceKm(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Coordinates
+		want float64
+	}{
+		{"same point", Coordinates{30.0444, 31.2357}, Coordinates{30.0444, 31.2357}, 0},
+		{"Cairo to Alexandria", Coordinates{30.0444, 31.2357}, Coordinates{31.2001, 29.9187}, 179},
+		{"Mecca to Medina", Coordinates{21.4225, 39.8262}, Coordinates{24.4672, 39.6111}, 339},
+		{"across antimeridian", Coordinates{0, 179.5}, Coordinates{0, -179.5}, 111},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceKm(tt.a, tt.b)
+			if got < tt.want-1 || got > tt.want+1 {
+				t.Errorf("DistanceKm() = %.1f, want ~%.0f", got, tt.want)
+			}
+		})
+	}
+}
+
 // Integration test - runs against live IP geolocation services
 func TestDetectFromIPIntegration(t *testing.T) {
 	if testing.Short() {
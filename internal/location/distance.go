@@ -0,0 +1,28 @@
+// Package location provides location detection and geocoding functionality
+package location
+
+import "math"
+
+// earthRadiusKm is the mean radius of the Earth in kilometers
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two coordinates in kilometers
+// using the haversine formula
+func DistanceKm(a, b Coordinates) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := (b.Latitude - a.Latitude) * math.Pi / 180
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// DistanceTo returns the great-circle distance to another location in kilometers
+func (l *Location) DistanceTo(other *Location) float64 {
+	return DistanceKm(
+		Coordinates{Latitude: l.Latitude, Longitude: l.Longitude},
+		Coordinates{Latitude: other.Latitude, Longitude: other.Longitude},
+	)
+}
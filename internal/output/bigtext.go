@@ -0,0 +1,79 @@
+package output
+
+import "strings"
+
+// bigGlyphs are the block-digit glyphs drawn by BigText, 5 rows high. A '#' is a filled cell.
+var bigGlyphs = map[rune][5]string{
+	'0': {"###", "# #", "# #", "# #", "###"},
+	'1': {"  #", "  #", "  #", "  #", "  #"},
+	'2': {"###", "  #", "###", "#  ", "###"},
+	'3': {"###", "  #", "###", "  #", "###"},
+	'4': {"# #", "# #", "###", "  #", "  #"},
+	'5': {"###", "#  ", "###", "  #", "###"},
+	'6': {"###", "#  ", "###", "# #", "###"},
+	'7': {"###", "  #", "  #", "  #", "  #"},
+	'8': {"###", "# #", "###", "# #", "###"},
+	'9': {"###", "# #", "###", "  #", "###"},
+	':': {" ", "#", " ", "#", " "},
+	' ': {" ", " ", " ", " ", " "},
+}
+
+// BigTextHeight is the number of rows of BigText at scale 1
+const BigTextHeight = 5
+
+// bigGlyphsOf returns the glyphs of the characters of s that BigText can draw
+func bigGlyphsOf(s string) [][5]string {
+	var glyphs [][5]string
+	for _, r := range s {
+		if glyph, ok := bigGlyphs[r]; ok {
+			glyphs = append(glyphs, glyph)
+		}
+	}
+	return glyphs
+}
+
+// BigTextWidth returns the width in cells of s drawn by BigText at the given scale
+func BigTextWidth(s string, scale int) int {
+	glyphs := bigGlyphsOf(s)
+	if len(glyphs) == 0 {
+		return 0
+	}
+	width := (len(glyphs) - 1) * 2 * scale // Gaps between glyphs
+	for _, glyph := range glyphs {
+		width += len(glyph[0]) * 2 * scale
+	}
+	return width
+}
+
+// BigText draws digits and colons as large block characters, BigTextHeight*scale rows high.
+// Cells are twice as wide as they are high, as terminal cells are about twice as high as wide.
+// Other characters are skipped. It returns nil for plain output.
+func (t *Theme) BigText(s string, scale int) []string {
+	if t.Plain || scale < 1 {
+		return nil
+	}
+	full := strings.Repeat(t.Box.BarFull, 2*scale)
+	empty := strings.Repeat(" ", 2*scale)
+
+	glyphs := bigGlyphsOf(s)
+	rows := make([]string, BigTextHeight*scale)
+	for row := 0; row < BigTextHeight; row++ {
+		var b strings.Builder
+		for i, glyph := range glyphs {
+			if i > 0 {
+				b.WriteString(empty)
+			}
+			for _, cell := range glyph[row] {
+				if cell == '#' {
+					b.WriteString(full)
+				} else {
+					b.WriteString(empty)
+				}
+			}
+		}
+		for i := 0; i < scale; i++ {
+			rows[row*scale+i] = b.String()
+		}
+	}
+	return rows
+}
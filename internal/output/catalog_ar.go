@@ -0,0 +1,127 @@
+// Package output provides output formatting for prayer times
+package output
+
+// catalogAr is the Arabic catalog
+var catalogAr = &Catalog{
+	Code:   "ar",
+	Name:   "Arabic",
+	Native: "العربية",
+	RTL:    true,
+	Digits: "٠١٢٣٤٥٦٧٨٩",
+	Clock:  "12h",
+	Messages: map[string]string{
+		// Prayer names
+		"prayer.Fajr":     "الفجر",
+		"prayer.Sunrise":  "الشروق",
+		"prayer.Dhuhr":    "الظهر",
+		"prayer.Asr":      "العصر",
+		"prayer.Maghrib":  "المغرب",
+		"prayer.Isha":     "العشاء",
+		"prayer.Midnight": "منتصف الليل",
+		"prayer.Jumu'ah":  "الجمعة",
+
+		// Headings
+		"heading.title":       "مواقيت الصلاة - %s",
+		"heading.titleFor":    "مواقيت الصلاة في %s",
+		"heading.prayerTimes": "مواقيت الصلاة",
+
+		// Table columns
+		"column.prayer": "الصلاة",
+		"column.time":   "الوقت",
+		"column.iqama":  "الإقامة",
+		"column.rakahs": "الركعات",
+		"column.status": "الحالة",
+		"column.date":   "التاريخ",
+		"column.hijri":  "الهجري",
+		"column.in":     "بعد",
+		"column.day":    "اليوم",
+
+		// Status
+		"status.passed":       "انتهت",
+		"status.next":         "التالية (بعد %s)",
+		"status.nextPrayer":   "الصلاة التالية بعد %s",
+		"status.nextTomorrow": "الصلاة التالية: %s غدًا الساعة %s (بعد %s)",
+
+		// Labels
+		"label.qibla":          "القبلة: %.1f° (%s)",
+		"label.qiblaDirection": "اتجاه القبلة: %s (%.1f°)",
+		"label.method":         "طريقة الحساب: %s",
+		"label.mosque":         "المسجد: %s",
+		"label.iqama":          "الإقامة %s",
+		"label.rakah":          "الركعات: %d",
+		"label.qasr":           "(قصر)",
+		"label.reminders":      "تذكير",
+		"label.generated":      "أُنشئ في %s بواسطة pray",
+		"label.location":       "الموقع: %s",
+		"label.goodbye":        "مع السلامة!",
+
+		// Traveler mode
+		"traveler.title":  "وضع المسافر: قصر وجمع",
+		"traveler.window": "%s: تقديم %s · تأخير %s · حتى %s",
+		"traveler.jam":    "جمع %s: %s-%s (تأخير %s)",
+
+		// Du'a
+		"dua.morning": "أذكار الصباح",
+		"dua.evening": "أذكار المساء",
+		"dua.daily":   "دعاء اليوم",
+
+		// Days
+		"day.today":    "اليوم",
+		"day.tomorrow": "غدًا",
+
+		// Next prayer
+		"next.title":        "الصلاة التالية: %s",
+		"next.count":        "الصلوات القادمة (%d)",
+		"next.none":         "لا توجد صلوات قادمة",
+		"next.allPassed":    "انقضت جميع صلوات اليوم",
+		"next.time":         "الوقت: %s",
+		"next.in":           "بعد: %s",
+		"next.iqama":        "الإقامة: %s",
+		"next.iqamaIn":      "(الإقامة بعد %s)",
+		"next.iqamaAt":      "(الإقامة %s)",
+		"next.until":        "بعد %s",
+		"next.tomorrow":     "(غدًا)",
+		"next.pendingIqama": "إقامة %s بعد %s (%s)",
+		"next.windowOpen":   "وقت %s مفتوح حتى %s (يتبقى %s)",
+
+		// Countdown
+		"countdown.title":            "العد التنازلي لمواقيت الصلاة",
+		"countdown.units":            "سا   دق   ثا",
+		"countdown.window":           "%s %s إلى %s %s",
+		"countdown.windowCloses":     "ينتهي وقت %s بعد %s (%s)",
+		"countdown.pendingIqama":     "إقامة %s بعد %s",
+		"countdown.announce":         "%s بعد %s، الساعة %s",
+		"countdown.announceTomorrow": "%s بعد %s، غدًا الساعة %s",
+		"countdown.offline":          "غير متصل: مواقيت اليوم تقديرية",
+		"countdown.exit":             "اضغط Ctrl+C للخروج",
+
+		// Clock
+		"time.am": "ص",
+		"time.pm": "م",
+
+		// Relative times
+		"duration.minutes":      "%d دقيقة",
+		"duration.hours":        "%d ساعة",
+		"duration.hoursMinutes": "%d س %d د",
+
+		// Punctuation
+		"separator.list": "، ",
+
+		// Errors
+		"error.noData": "لا توجد بيانات لمواقيت الصلاة",
+
+		// Gregorian months
+		"month.1":  "يناير",
+		"month.2":  "فبراير",
+		"month.3":  "مارس",
+		"month.4":  "أبريل",
+		"month.5":  "مايو",
+		"month.6":  "يونيو",
+		"month.7":  "يوليو",
+		"month.8":  "أغسطس",
+		"month.9":  "سبتمبر",
+		"month.10": "أكتوبر",
+		"month.11": "نوفمبر",
+		"month.12": "ديسمبر",
+	},
+}
@@ -0,0 +1,111 @@
+// Package output provides output formatting for prayer times
+package output
+
+// catalogEn is the English catalog and the fallback for missing labels
+var catalogEn = &Catalog{
+	Code:   "en",
+	Name:   "English",
+	Native: "English",
+	Clock:  "12h",
+	Messages: map[string]string{
+		// Prayer names
+		"prayer.Fajr":     "Fajr",
+		"prayer.Sunrise":  "Sunrise",
+		"prayer.Dhuhr":    "Dhuhr",
+		"prayer.Asr":      "Asr",
+		"prayer.Maghrib":  "Maghrib",
+		"prayer.Isha":     "Isha",
+		"prayer.Midnight": "Midnight",
+		"prayer.Jumu'ah":  "Jumu'ah",
+
+		// Headings
+		"heading.title":       "Prayer Times - %s",
+		"heading.titleFor":    "Prayer Times for %s",
+		"heading.prayerTimes": "Prayer Times",
+
+		// Table columns
+		"column.prayer": "Prayer",
+		"column.time":   "Time",
+		"column.iqama":  "Iqama",
+		"column.rakahs": "Rakahs",
+		"column.status": "Status",
+		"column.date":   "Date",
+		"column.hijri":  "Hijri",
+		"column.in":     "In",
+		"column.day":    "Day",
+
+		// Status
+		"status.passed":       "Passed",
+		"status.next":         "Next (in %s)",
+		"status.nextPrayer":   "Next prayer in %s",
+		"status.nextTomorrow": "Next prayer: %s tomorrow at %s (in %s)",
+
+		// Labels
+		"label.qibla":          "Qibla: %.1f° (%s)",
+		"label.qiblaDirection": "Qibla Direction: %s (%.1f°)",
+		"label.method":         "Method: %s",
+		"label.mosque":         "Mosque: %s",
+		"label.iqama":          "Iqama %s",
+		"label.rakah":          "%d rak'ah",
+		"label.qasr":           "(qasr)",
+		"label.reminders":      "Reminders",
+		"label.generated":      "Generated %s by pray",
+		"label.location":       "Location: %s",
+		"label.goodbye":        "Goodbye!",
+
+		// Traveler mode
+		"traveler.title":  "Traveler mode: Qasr & Jam'",
+		"traveler.window": "%s: taqdim %s · ta'khir %s · until %s",
+		"traveler.jam":    "Jam' %s: %s-%s (ta'khir %s)",
+
+		// Du'a
+		"dua.morning": "Morning Adhkar",
+		"dua.evening": "Evening Adhkar",
+		"dua.daily":   "Today's Du'a",
+
+		// Days
+		"day.today":    "Today",
+		"day.tomorrow": "Tomorrow",
+
+		// Next prayer
+		"next.title":        "Next Prayer: %s",
+		"next.count":        "Next %d prayers",
+		"next.none":         "No upcoming prayers",
+		"next.allPassed":    "All prayers for today have passed",
+		"next.time":         "Time: %s",
+		"next.in":           "In:   %s",
+		"next.iqama":        "Iqama: %s",
+		"next.iqamaIn":      "(iqama in %s)",
+		"next.iqamaAt":      "(iqama %s)",
+		"next.until":        "in %s",
+		"next.tomorrow":     "(tomorrow)",
+		"next.pendingIqama": "%s iqama in %s (%s)",
+		"next.windowOpen":   "%s window open until %s (%s left)",
+
+		// Countdown
+		"countdown.title":            "Prayer Time Countdown",
+		"countdown.units":            "hr   min   sec",
+		"countdown.window":           "%s %s to %s %s",
+		"countdown.windowCloses":     "%s window closes in %s (%s)",
+		"countdown.pendingIqama":     "%s iqama in %s",
+		"countdown.announce":         "%s in %s, at %s",
+		"countdown.announceTomorrow": "%s in %s, at %s tomorrow",
+		"countdown.offline":          "Offline: today's times are estimated",
+		"countdown.exit":             "Press Ctrl+C to exit",
+
+		// Clock
+		"time.am": "AM",
+		"time.pm": "PM",
+
+		// Relative times
+		"duration.minutes":      "%d min",
+		"duration.hours":        "%dh",
+		"duration.hoursMinutes": "%dh %dm",
+
+		// Punctuation
+		"separator.list": ", ",
+
+		// Errors
+		"error.noData": "no prayer times data",
+	},
+}
@@ -0,0 +1,134 @@
+// Package output provides output formatting for prayer times
+package output
+
+// catalogFr is the French catalog
+var catalogFr = &Catalog{
+	Code:   "fr",
+	Name:   "French",
+	Native: "Français",
+	Clock:  "24h",
+	Messages: map[string]string{
+		// Prayer names
+		"prayer.Fajr":     "Fajr",
+		"prayer.Sunrise":  "Chourouk",
+		"prayer.Dhuhr":    "Dhohr",
+		"prayer.Asr":      "Asr",
+		"prayer.Maghrib":  "Maghrib",
+		"prayer.Isha":     "Icha",
+		"prayer.Midnight": "Minuit",
+		"prayer.Jumu'ah":  "Joumou'a",
+
+		// Headings
+		"heading.title":       "Horaires de prière - %s",
+		"heading.titleFor":    "Horaires de prière pour %s",
+		"heading.prayerTimes": "Horaires de prière",
+
+		// Table columns
+		"column.prayer": "Prière",
+		"column.time":   "Heure",
+		"column.iqama":  "Iqama",
+		"column.rakahs": "Rak'as",
+		"column.status": "Statut",
+		"column.date":   "Date",
+		"column.hijri":  "Hégire",
+		"column.in":     "Dans",
+		"column.day":    "Jour",
+
+		// Status
+		"status.passed":       "Passée",
+		"status.next":         "Prochaine (dans %s)",
+		"status.nextPrayer":   "Prochaine prière dans %s",
+		"status.nextTomorrow": "Prochaine prière : %s demain à %s (dans %s)",
+
+		// Labels
+		"label.qibla":          "Qibla : %.1f° (%s)",
+		"label.qiblaDirection": "Direction de la Qibla : %s (%.1f°)",
+		"label.method":         "Méthode : %s",
+		"label.mosque":         "Mosquée : %s",
+		"label.iqama":          "Iqama %s",
+		"label.rakah":          "%d rak'as",
+		"label.qasr":           "(qasr)",
+		"label.reminders":      "Rappels",
+		"label.generated":      "Généré le %s par pray",
+		"label.location":       "Lieu : %s",
+		"label.goodbye":        "Au revoir !",
+
+		// Traveler mode
+		"traveler.title":  "Mode voyageur : Qasr et Jam'",
+		"traveler.window": "%s : taqdim %s · ta'khir %s · jusqu'à %s",
+		"traveler.jam":    "Jam' %s : %s-%s (ta'khir %s)",
+
+		// Du'a
+		"dua.morning": "Invocations du matin",
+		"dua.evening": "Invocations du soir",
+		"dua.daily":   "Invocation du jour",
+
+		// Days
+		"day.today":    "Aujourd'hui",
+		"day.tomorrow": "Demain",
+
+		// Next prayer
+		"next.title":        "Prochaine prière : %s",
+		"next.count":        "Les %d prochaines prières",
+		"next.none":         "Aucune prière à venir",
+		"next.allPassed":    "Toutes les prières d'aujourd'hui sont passées",
+		"next.time":         "Heure : %s",
+		"next.in":           "Dans : %s",
+		"next.iqama":        "Iqama : %s",
+		"next.iqamaIn":      "(iqama dans %s)",
+		"next.iqamaAt":      "(iqama %s)",
+		"next.until":        "dans %s",
+		"next.tomorrow":     "(demain)",
+		"next.pendingIqama": "Iqama de %s dans %s (%s)",
+		"next.windowOpen":   "Fenêtre %s ouverte jusqu'à %s (encore %s)",
+
+		// Countdown
+		"countdown.title":            "Compte à rebours des prières",
+		"countdown.units":            "h    min   s",
+		"countdown.window":           "%s %s à %s %s",
+		"countdown.windowCloses":     "La fenêtre %s se ferme dans %s (%s)",
+		"countdown.pendingIqama":     "Iqama de %s dans %s",
+		"countdown.announce":         "%s dans %s, à %s",
+		"countdown.announceTomorrow": "%s dans %s, demain à %s",
+		"countdown.offline":          "Hors ligne : les horaires d'aujourd'hui sont estimés",
+		"countdown.exit":             "Appuyez sur Ctrl+C pour quitter",
+
+		// Clock
+		"time.am": "AM",
+		"time.pm": "PM",
+
+		// Relative times
+		"duration.minutes":      "%d min",
+		"duration.hours":        "%d h",
+		"duration.hoursMinutes": "%d h %d min",
+
+		// Punctuation
+		"separator.list": ", ",
+
+		// Errors
+		"error.noData": "aucune donnée d'horaires de prière",
+
+		// Gregorian months
+		"month.1":  "janvier",
+		"month.2":  "février",
+		"month.3":  "mars",
+		"month.4":  "avril",
+		"month.5":  "mai",
+		"month.6":  "juin",
+		"month.7":  "juillet",
+		"month.8":  "août",
+		"month.9":  "septembre",
+		"month.10": "octobre",
+		"month.11": "novembre",
+		"month.12": "décembre",
+
+		// Weekdays
+		"weekday.Monday":    "lundi",
+		"weekday.Tuesday":   "mardi",
+		"weekday.Wednesday": "mercredi",
+		"weekday.Thursday":  "jeudi",
+		"weekday.Friday":    "vendredi",
+		"weekday.Saturday":  "samedi",
+		"weekday.Sunday":    "dimanche",
+	},
+}
@@ -0,0 +1,134 @@
+// Package output provides output formatting for prayer times
+package output
+
+// catalogID is the Indonesian catalog
+var catalogID = &Catalog{
+	Code:   "id",
+	Name:   "Indonesian",
+	Native: "Bahasa Indonesia",
+	Clock:  "24h",
+	Messages: map[string]string{
+		// Prayer names
+		"prayer.Fajr":     "Subuh",
+		"prayer.Sunrise":  "Terbit",
+		"prayer.Dhuhr":    "Zuhur",
+		"prayer.Asr":      "Asar",
+		"prayer.Maghrib":  "Magrib",
+		"prayer.Isha":     "Isya",
+		"prayer.Midnight": "Tengah Malam",
+		"prayer.Jumu'ah":  "Jumat",
+
+		// Headings
+		"heading.title":       "Jadwal Salat - %s",
+		"heading.titleFor":    "Jadwal Salat untuk %s",
+		"heading.prayerTimes": "Jadwal Salat",
+
+		// Table columns
+		"column.prayer": "Salat",
+		"column.time":   "Waktu",
+		"column.iqama":  "Iqamah",
+		"column.rakahs": "Rakaat",
+		"column.status": "Status",
+		"column.date":   "Tanggal",
+		"column.hijri":  "Hijriah",
+		"column.in":     "Dalam",
+		"column.day":    "Hari",
+
+		// Status
+		"status.passed":       "Lewat",
+		"status.next":         "Berikutnya (%s lagi)",
+		"status.nextPrayer":   "Salat berikutnya %s lagi",
+		"status.nextTomorrow": "Salat berikutnya: %s besok pukul %s (%s lagi)",
+
+		// Labels
+		"label.qibla":          "Kiblat: %.1f° (%s)",
+		"label.qiblaDirection": "Arah Kiblat: %s (%.1f°)",
+		"label.method":         "Metode: %s",
+		"label.mosque":         "Masjid: %s",
+		"label.iqama":          "Iqamah %s",
+		"label.rakah":          "%d rakaat",
+		"label.qasr":           "(qashar)",
+		"label.reminders":      "Pengingat",
+		"label.generated":      "Dibuat %s oleh pray",
+		"label.location":       "Lokasi: %s",
+		"label.goodbye":        "Sampai jumpa!",
+
+		// Traveler mode
+		"traveler.title":  "Mode musafir: Qashar & Jamak",
+		"traveler.window": "%s: taqdim %s · ta'khir %s · hingga %s",
+		"traveler.jam":    "Jamak %s: %s-%s (ta'khir %s)",
+
+		// Du'a
+		"dua.morning": "Dzikir Pagi",
+		"dua.evening": "Dzikir Petang",
+		"dua.daily":   "Doa Hari Ini",
+
+		// Days
+		"day.today":    "Hari ini",
+		"day.tomorrow": "Besok",
+
+		// Next prayer
+		"next.title":        "Salat Berikutnya: %s",
+		"next.count":        "%d salat berikutnya",
+		"next.none":         "Tidak ada salat berikutnya",
+		"next.allPassed":    "Semua waktu salat hari ini telah lewat",
+		"next.time":         "Waktu: %s",
+		"next.in":           "Dalam: %s",
+		"next.iqama":        "Iqamah: %s",
+		"next.iqamaIn":      "(iqamah %s lagi)",
+		"next.iqamaAt":      "(iqamah %s)",
+		"next.until":        "%s lagi",
+		"next.tomorrow":     "(besok)",
+		"next.pendingIqama": "Iqamah %s %s lagi (%s)",
+		"next.windowOpen":   "Waktu %s terbuka hingga %s (sisa %s)",
+
+		// Countdown
+		"countdown.title":            "Hitung Mundur Waktu Salat",
+		"countdown.units":            "jam  mnt  dtk",
+		"countdown.window":           "%s %s sampai %s %s",
+		"countdown.windowCloses":     "Waktu %s berakhir %s lagi (%s)",
+		"countdown.pendingIqama":     "Iqamah %s %s lagi",
+		"countdown.announce":         "%s %s lagi, pukul %s",
+		"countdown.announceTomorrow": "%s %s lagi, besok pukul %s",
+		"countdown.offline":          "Luring: waktu hari ini adalah perkiraan",
+		"countdown.exit":             "Tekan Ctrl+C untuk keluar",
+
+		// Clock
+		"time.am": "AM",
+		"time.pm": "PM",
+
+		// Relative times
+		"duration.minutes":      "%d mnt",
+		"duration.hours":        "%d jam",
+		"duration.hoursMinutes": "%d jam %d mnt",
+
+		// Punctuation
+		"separator.list": ", ",
+
+		// Errors
+		"error.noData": "tidak ada data jadwal salat",
+
+		// Gregorian months
+		"month.1":  "Januari",
+		"month.2":  "Februari",
+		"month.3":  "Maret",
+		"month.4":  "April",
+		"month.5":  "Mei",
+		"month.6":  "Juni",
+		"month.7":  "Juli",
+		"month.8":  "Agustus",
+		"month.9":  "September",
+		"month.10": "Oktober",
+		"month.11": "November",
+		"month.12": "Desember",
+
+		// Weekdays
+		"weekday.Monday":    "Senin",
+		"weekday.Tuesday":   "Selasa",
+		"weekday.Wednesday": "Rabu",
+		"weekday.Thursday":  "Kamis",
+		"weekday.Friday":    "Jumat",
+		"weekday.Saturday":  "Sabtu",
+		"weekday.Sunday":    "Minggu",
+	},
+}
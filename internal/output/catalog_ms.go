@@ -0,0 +1,134 @@
+// Package output provides output formatting for prayer times
+package output
+
+// catalogMs is the Malay catalog
+var catalogMs = &Catalog{
+	Code:   "ms",
+	Name:   "Malay",
+	Native: "Bahasa Melayu",
+	Clock:  "12h",
+	Messages: map[string]string{
+		// Prayer names
+		"prayer.Fajr":     "Subuh",
+		"prayer.Sunrise":  "Syuruk",
+		"prayer.Dhuhr":    "Zohor",
+		"prayer.Asr":      "Asar",
+		"prayer.Maghrib":  "Maghrib",
+		"prayer.Isha":     "Isyak",
+		"prayer.Midnight": "Tengah Malam",
+		"prayer.Jumu'ah":  "Jumaat",
+
+		// Headings
+		"heading.title":       "Waktu Solat - %s",
+		"heading.titleFor":    "Waktu Solat untuk %s",
+		"heading.prayerTimes": "Waktu Solat",
+
+		// Table columns
+		"column.prayer": "Solat",
+		"column.time":   "Waktu",
+		"column.iqama":  "Iqamah",
+		"column.rakahs": "Rakaat",
+		"column.status": "Status",
+		"column.date":   "Tarikh",
+		"column.hijri":  "Hijrah",
+		"column.in":     "Dalam",
+		"column.day":    "Hari",
+
+		// Status
+		"status.passed":       "Berlalu",
+		"status.next":         "Seterusnya (%s lagi)",
+		"status.nextPrayer":   "Solat seterusnya dalam %s",
+		"status.nextTomorrow": "Solat seterusnya: %s esok pada %s (dalam %s)",
+
+		// Labels
+		"label.qibla":          "Kiblat: %.1f° (%s)",
+		"label.qiblaDirection": "Arah Kiblat: %s (%.1f°)",
+		"label.method":         "Kaedah: %s",
+		"label.mosque":         "Masjid: %s",
+		"label.iqama":          "Iqamah %s",
+		"label.rakah":          "%d rakaat",
+		"label.qasr":           "(qasar)",
+		"label.reminders":      "Peringatan",
+		"label.generated":      "Dijana %s oleh pray",
+		"label.location":       "Lokasi: %s",
+		"label.goodbye":        "Selamat tinggal!",
+
+		// Traveler mode
+		"traveler.title":  "Mod musafir: Qasar & Jamak",
+		"traveler.window": "%s: taqdim %s · ta'khir %s · hingga %s",
+		"traveler.jam":    "Jamak %s: %s-%s (ta'khir %s)",
+
+		// Du'a
+		"dua.morning": "Zikir Pagi",
+		"dua.evening": "Zikir Petang",
+		"dua.daily":   "Doa Hari Ini",
+
+		// Days
+		"day.today":    "Hari ini",
+		"day.tomorrow": "Esok",
+
+		// Next prayer
+		"next.title":        "Solat Seterusnya: %s",
+		"next.count":        "%d solat seterusnya",
+		"next.none":         "Tiada solat akan datang",
+		"next.allPassed":    "Semua waktu solat hari ini telah berlalu",
+		"next.time":         "Waktu: %s",
+		"next.in":           "Dalam: %s",
+		"next.iqama":        "Iqamah: %s",
+		"next.iqamaIn":      "(iqamah dalam %s)",
+		"next.iqamaAt":      "(iqamah %s)",
+		"next.until":        "dalam %s",
+		"next.tomorrow":     "(esok)",
+		"next.pendingIqama": "Iqamah %s dalam %s (%s)",
+		"next.windowOpen":   "Waktu %s dibuka hingga %s (baki %s)",
+
+		// Countdown
+		"countdown.title":            "Kira Detik Waktu Solat",
+		"countdown.units":            "jam  min  saat",
+		"countdown.window":           "%s %s hingga %s %s",
+		"countdown.windowCloses":     "Waktu %s tamat dalam %s (%s)",
+		"countdown.pendingIqama":     "Iqamah %s dalam %s",
+		"countdown.announce":         "%s dalam %s, pada %s",
+		"countdown.announceTomorrow": "%s dalam %s, esok pada %s",
+		"countdown.offline":          "Luar talian: waktu hari ini adalah anggaran",
+		"countdown.exit":             "Tekan Ctrl+C untuk keluar",
+
+		// Clock
+		"time.am": "PG",
+		"time.pm": "PTG",
+
+		// Relative times
+		"duration.minutes":      "%d min",
+		"duration.hours":        "%d jam",
+		"duration.hoursMinutes": "%d jam %d min",
+
+		// Punctuation
+		"separator.list": ", ",
+
+		// Errors
+		"error.noData": "tiada data waktu solat",
+
+		// Gregorian months
+		"month.1":  "Januari",
+		"month.2":  "Februari",
+		"month.3":  "Mac",
+		"month.4":  "April",
+		"month.5":  "Mei",
+		"month.6":  "Jun",
+		"month.7":  "Julai",
+		"month.8":  "Ogos",
+		"month.9":  "September",
+		"month.10": "Oktober",
+		"month.11": "November",
+		"month.12": "Disember",
+
+		// Weekdays
+		"weekday.Monday":    "Isnin",
+		"weekday.Tuesday":   "Selasa",
+		"weekday.Wednesday": "Rabu",
+		"weekday.Thursday":  "Khamis",
+		"weekday.Friday":    "Jumaat",
+		"weekday.Saturday":  "Sabtu",
+		"weekday.Sunday":    "Ahad",
+	},
+}
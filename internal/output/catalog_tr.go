@@ -0,0 +1,134 @@
+// Package output provides output formatting for prayer times
+package output
+
+// catalogTr is the Turkish catalog
+var catalogTr = &Catalog{
+	Code:   "tr",
+	Name:   "Turkish",
+	Native: "Türkçe",
+	Clock:  "24h",
+	Messages: map[string]string{
+		// Prayer names
+		"prayer.Fajr":     "İmsak",
+		"prayer.Sunrise":  "Güneş",
+		"prayer.Dhuhr":    "Öğle",
+		"prayer.Asr":      "İkindi",
+		"prayer.Maghrib":  "Akşam",
+		"prayer.Isha":     "Yatsı",
+		"prayer.Midnight": "Gece Yarısı",
+		"prayer.Jumu'ah":  "Cuma",
+
+		// Headings
+		"heading.title":       "Namaz Vakitleri - %s",
+		"heading.titleFor":    "%s Namaz Vakitleri",
+		"heading.prayerTimes": "Namaz Vakitleri",
+
+		// Table columns
+		"column.prayer": "Vakit",
+		"column.time":   "Saat",
+		"column.iqama":  "Kamet",
+		"column.rakahs": "Rekât",
+		"column.status": "Durum",
+		"column.date":   "Tarih",
+		"column.hijri":  "Hicri",
+		"column.in":     "Kalan",
+		"column.day":    "Gün",
+
+		// Status
+		"status.passed":       "Geçti",
+		"status.next":         "Sıradaki (%s sonra)",
+		"status.nextPrayer":   "Sonraki namaz %s sonra",
+		"status.nextTomorrow": "Sonraki namaz: %s, yarın %s (%s sonra)",
+
+		// Labels
+		"label.qibla":          "Kıble: %.1f° (%s)",
+		"label.qiblaDirection": "Kıble Yönü: %s (%.1f°)",
+		"label.method":         "Hesaplama: %s",
+		"label.mosque":         "Cami: %s",
+		"label.iqama":          "Kamet %s",
+		"label.rakah":          "%d rekât",
+		"label.qasr":           "(kasr)",
+		"label.reminders":      "Hatırlatmalar",
+		"label.generated":      "%s tarihinde pray ile oluşturuldu",
+		"label.location":       "Konum: %s",
+		"label.goodbye":        "Hoşça kalın!",
+
+		// Traveler mode
+		"traveler.title":  "Yolcu modu: Kasr ve Cem",
+		"traveler.window": "%s: takdim %s · tehir %s · bitiş %s",
+		"traveler.jam":    "Cem %s: %s-%s (tehir %s)",
+
+		// Du'a
+		"dua.morning": "Sabah Zikirleri",
+		"dua.evening": "Akşam Zikirleri",
+		"dua.daily":   "Günün Duası",
+
+		// Days
+		"day.today":    "Bugün",
+		"day.tomorrow": "Yarın",
+
+		// Next prayer
+		"next.title":        "Sonraki Namaz: %s",
+		"next.count":        "Sonraki %d namaz",
+		"next.none":         "Yaklaşan namaz yok",
+		"next.allPassed":    "Bugünün tüm namaz vakitleri geçti",
+		"next.time":         "Vakit: %s",
+		"next.in":           "Kalan: %s",
+		"next.iqama":        "Kamet: %s",
+		"next.iqamaIn":      "(kamete %s kaldı)",
+		"next.iqamaAt":      "(kamet %s)",
+		"next.until":        "%s sonra",
+		"next.tomorrow":     "(yarın)",
+		"next.pendingIqama": "%s kameti %s sonra (%s)",
+		"next.windowOpen":   "%s vakti %s saatine kadar açık (%s kaldı)",
+
+		// Countdown
+		"countdown.title":            "Namaz Vakti Geri Sayımı",
+		"countdown.units":            "sa   dk   sn",
+		"countdown.window":           "%s %s - %s %s",
+		"countdown.windowCloses":     "%s vakti %s sonra kapanıyor (%s)",
+		"countdown.pendingIqama":     "%s kameti %s sonra",
+		"countdown.announce":         "%s %s sonra, saat %s",
+		"countdown.announceTomorrow": "%s %s sonra, yarın saat %s",
+		"countdown.offline":          "Çevrimdışı: bugünün vakitleri tahminidir",
+		"countdown.exit":             "Çıkmak için Ctrl+C'ye basın",
+
+		// Clock
+		"time.am": "ÖÖ",
+		"time.pm": "ÖS",
+
+		// Relative times
+		"duration.minutes":      "%d dk",
+		"duration.hours":        "%d sa",
+		"duration.hoursMinutes": "%d sa %d dk",
+
+		// Punctuation
+		"separator.list": ", ",
+
+		// Errors
+		"error.noData": "namaz vakti verisi yok",
+
+		// Gregorian months
+		"month.1":  "Ocak",
+		"month.2":  "Şubat",
+		"month.3":  "Mart",
+		"month.4":  "Nisan",
+		"month.5":  "Mayıs",
+		"month.6":  "Haziran",
+		"month.7":  "Temmuz",
+		"month.8":  "Ağustos",
+		"month.9":  "Eylül",
+		"month.10": "Ekim",
+		"month.11": "Kasım",
+		"month.12": "Aralık",
+
+		// Weekdays
+		"weekday.Monday":    "Pazartesi",
+		"weekday.Tuesday":   "Salı",
+		"weekday.Wednesday": "Çarşamba",
+		"weekday.Thursday":  "Perşembe",
+		"weekday.Friday":    "Cuma",
+		"weekday.Saturday":  "Cumartesi",
+		"weekday.Sunday":    "Pazar",
+	},
+}
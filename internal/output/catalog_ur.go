@@ -0,0 +1,136 @@
+// Package output provides output formatting for prayer times
+package output
+
+// catalogUr is the Urdu catalog
+var catalogUr = &Catalog{
+	Code:   "ur",
+	Name:   "Urdu",
+	Native: "اردو",
+	RTL:    true,
+	Digits: "۰۱۲۳۴۵۶۷۸۹",
+	Clock:  "12h",
+	Messages: map[string]string{
+		// Prayer names
+		"prayer.Fajr":     "فجر",
+		"prayer.Sunrise":  "طلوعِ آفتاب",
+		"prayer.Dhuhr":    "ظہر",
+		"prayer.Asr":      "عصر",
+		"prayer.Maghrib":  "مغرب",
+		"prayer.Isha":     "عشاء",
+		"prayer.Midnight": "آدھی رات",
+		"prayer.Jumu'ah":  "جمعہ",
+
+		// Headings
+		"heading.title":       "اوقاتِ نماز - %s",
+		"heading.titleFor":    "%s کے اوقاتِ نماز",
+		"heading.prayerTimes": "اوقاتِ نماز",
+
+		// Table columns
+		"column.prayer": "نماز",
+		"column.time":   "وقت",
+		"column.iqama":  "اقامت",
+		"column.rakahs": "رکعات",
+		"column.status": "حالت",
+		"column.date":   "تاریخ",
+		"column.hijri":  "ہجری",
+		"column.in":     "باقی",
+		"column.day":    "دن",
+
+		// Status
+		"status.passed":       "گزر گئی",
+		"status.next":         "اگلی (%s میں)",
+		"status.nextPrayer":   "اگلی نماز %s میں",
+		"status.nextTomorrow": "اگلی نماز: %s کل %s بجے (%s میں)",
+
+		// Labels
+		"label.qibla":          "قبلہ: %.1f° (%s)",
+		"label.qiblaDirection": "سمتِ قبلہ: %s (%.1f°)",
+		"label.method":         "طریقۂ حساب: %s",
+		"label.mosque":         "مسجد: %s",
+		"label.iqama":          "اقامت %s",
+		"label.rakah":          "%d رکعت",
+		"label.qasr":           "(قصر)",
+		"label.reminders":      "یاد دہانیاں",
+		"label.generated":      "pray سے %s کو تیار کیا گیا",
+		"label.location":       "مقام: %s",
+		"label.goodbye":        "خدا حافظ!",
+
+		// Traveler mode
+		"traveler.title":  "مسافر موڈ: قصر و جمع",
+		"traveler.window": "%s: تقدیم %s · تاخیر %s · %s تک",
+		"traveler.jam":    "جمع %s: %s-%s (تاخیر %s)",
+
+		// Du'a
+		"dua.morning": "صبح کے اذکار",
+		"dua.evening": "شام کے اذکار",
+		"dua.daily":   "آج کی دعا",
+
+		// Days
+		"day.today":    "آج",
+		"day.tomorrow": "کل",
+
+		// Next prayer
+		"next.title":        "اگلی نماز: %s",
+		"next.count":        "اگلی %d نمازیں",
+		"next.none":         "کوئی آنے والی نماز نہیں",
+		"next.allPassed":    "آج کی تمام نمازوں کا وقت گزر چکا ہے",
+		"next.time":         "وقت: %s",
+		"next.in":           "باقی: %s",
+		"next.iqama":        "اقامت: %s",
+		"next.iqamaIn":      "(اقامت %s میں)",
+		"next.iqamaAt":      "(اقامت %s)",
+		"next.until":        "%s میں",
+		"next.tomorrow":     "(کل)",
+		"next.pendingIqama": "%s کی اقامت %s میں (%s)",
+		"next.windowOpen":   "%s کا وقت %s تک (%s باقی)",
+
+		// Countdown
+		"countdown.title":            "نماز کے وقت کی الٹی گنتی",
+		"countdown.units":            "گھنٹے  منٹ  سیکنڈ",
+		"countdown.window":           "%s %s سے %s %s تک",
+		"countdown.windowCloses":     "%s کا وقت %s میں ختم (%s)",
+		"countdown.pendingIqama":     "%s کی اقامت %s میں",
+		"countdown.announce":         "%s %s میں، %s بجے",
+		"countdown.announceTomorrow": "%s %s میں، کل %s بجے",
+		"countdown.offline":          "آف لائن: آج کے اوقات تخمینی ہیں",
+		"countdown.exit":             "باہر نکلنے کے لیے Ctrl+C دبائیں",
+
+		// Clock
+		"time.am": "AM",
+		"time.pm": "PM",
+
+		// Relative times
+		"duration.minutes":      "%d منٹ",
+		"duration.hours":        "%d گھنٹے",
+		"duration.hoursMinutes": "%d گھنٹے %d منٹ",
+
+		// Punctuation
+		"separator.list": "، ",
+
+		// Errors
+		"error.noData": "اوقاتِ نماز کا ڈیٹا موجود نہیں",
+
+		// Gregorian months
+		"month.1":  "جنوری",
+		"month.2":  "فروری",
+		"month.3":  "مارچ",
+		"month.4":  "اپریل",
+		"month.5":  "مئی",
+		"month.6":  "جون",
+		"month.7":  "جولائی",
+		"month.8":  "اگست",
+		"month.9":  "ستمبر",
+		"month.10": "اکتوبر",
+		"month.11": "نومبر",
+		"month.12": "دسمبر",
+
+		// Weekdays
+		"weekday.Monday":    "پیر",
+		"weekday.Tuesday":   "منگل",
+		"weekday.Wednesday": "بدھ",
+		"weekday.Thursday":  "جمعرات",
+		"weekday.Friday":    "جمعہ",
+		"weekday.Saturday":  "ہفتہ",
+		"weekday.Sunday":    "اتوار",
+	},
+}
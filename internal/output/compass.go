@@ -0,0 +1,51 @@
+package output
+
+import (
+	"math"
+	"strings"
+)
+
+// Compass draws a compass rose of the given radius with a needle pointing in direction
+// (degrees clockwise from north), e.g., the Qibla. It is 2*radius+1 rows of 4*radius+1
+// cells, as terminal cells are about twice as high as wide. It returns nil for plain
+// output or a radius too small to draw.
+func (t *Theme) Compass(direction float64, radius int) []string {
+	if t.Plain || radius < 2 {
+		return nil
+	}
+
+	grid := make([][]string, 2*radius+1)
+	for y := range grid {
+		grid[y] = make([]string, 4*radius+1)
+		for x := range grid[y] {
+			grid[y][x] = " "
+		}
+	}
+	plot := func(degrees, r float64, s string) {
+		rad := degrees * math.Pi / 180
+		x := 2*radius + int(math.Round(2*r*math.Sin(rad)))
+		y := radius - int(math.Round(r*math.Cos(rad)))
+		grid[y][x] = s
+	}
+
+	// Ring, with enough points to leave no gaps
+	steps := 16 * radius
+	for i := 0; i < steps; i++ {
+		plot(float64(i)*360/float64(steps), float64(radius), t.Box.Dot)
+	}
+	for i, label := range []string{"N", "E", "S", "W"} {
+		plot(float64(i*90), float64(radius), label)
+	}
+
+	// Needle from the center
+	for r := 0.5; r <= float64(radius)-1; r += 0.5 {
+		plot(direction, r, t.Box.BarFull)
+	}
+	plot(0, 0, "+")
+
+	rows := make([]string, len(grid))
+	for y, row := range grid {
+		rows[y] = strings.Join(row, "")
+	}
+	return rows
+}
@@ -0,0 +1,144 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// CSVFormatter formats output as comma-separated values, one row per day
+type CSVFormatter struct{}
+
+// TSVFormatter formats output as tab-separated values, one row per day
+type TSVFormatter struct{}
+
+// DefaultDateFormat is the Go layout of the date column of delimited output
+const DefaultDateFormat = "2006-01-02"
+
+// delimitedHeader is the stable header of CSV and TSV output, followed by
+// delimitedIqamaHeader when Iqama times are enabled
+var (
+	delimitedHeader      = []string{"date", "hijri", "fajr", "sunrise", "dhuhr", "asr", "maghrib", "isha", "midnight"}
+	delimitedIqamaHeader = []string{"fajr_iqama", "dhuhr_iqama", "asr_iqama", "maghrib_iqama", "isha_iqama"}
+)
+
+// ParseDelimiter returns the field delimiter for a setting: a single character,
+// or "tab" (or "\t") for a tab. An empty setting is a comma.
+func ParseDelimiter(s string) (rune, error) {
+	switch s {
+	case "":
+		return ',', nil
+	case "tab", `\t`:
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("invalid delimiter %q: must be a single character or \"tab\"", s)
+	}
+	return r, nil
+}
+
+// Format writes the prayer times as a CSV header and row
+func (f *CSVFormatter) Format(w io.Writer, data *PrayerData) error {
+	return f.FormatRange(w, []*PrayerData{data})
+}
+
+// FormatRange writes the prayer times of several days as CSV, one row per day
+func (f *CSVFormatter) FormatRange(w io.Writer, days []*PrayerData) error {
+	comma := ','
+	if len(days) > 0 && days[0] != nil {
+		var err error
+		if comma, err = ParseDelimiter(days[0].Delimiter); err != nil {
+			return err
+		}
+	}
+	return writeDelimited(w, days, comma)
+}
+
+// Format writes the prayer times as a TSV header and row
+func (f *TSVFormatter) Format(w io.Writer, data *PrayerData) error {
+	return f.FormatRange(w, []*PrayerData{data})
+}
+
+// FormatRange writes the prayer times of several days as TSV, one row per day
+func (f *TSVFormatter) FormatRange(w io.Writer, days []*PrayerData) error {
+	return writeDelimited(w, days, '\t')
+}
+
+// writeDelimited writes the days as delimited rows under the stable header. Times are
+// HH:MM on the 24-hour clock with Latin digits, whatever the display settings, so
+// spreadsheets can read them.
+func writeDelimited(w io.Writer, days []*PrayerData, comma rune) error {
+	if len(days) == 0 {
+		return errors.New("no prayer times data")
+	}
+	for _, data := range days {
+		if data == nil || data.Response == nil || data.Day == nil {
+			return errors.New("no prayer times data")
+		}
+	}
+
+	hasIqama := false
+	for _, data := range days {
+		hasIqama = hasIqama || data.HasIqama()
+	}
+	header := delimitedHeader
+	if hasIqama {
+		header = append(append([]string(nil), delimitedHeader...), delimitedIqamaHeader...)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, data := range days {
+		if err := cw.Write(delimitedRow(data, hasIqama)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// delimitedRow returns the day's fields in the order of the header
+func delimitedRow(data *PrayerData, iqama bool) []string {
+	layout := data.DateFormat
+	if layout == "" {
+		layout = DefaultDateFormat
+	}
+	day := data.Day
+	times := AdhanTimes(data.Response.Data.Timings)
+
+	row := []string{day.Date.Format(layout), ""}
+	if data.ShowHijri && data.HijriFormat != "none" {
+		row[1] = hijriISO(day.Hijri.Year, day.Hijri.Month, day.Hijri.Day)
+	}
+	for _, name := range []string{"Fajr", "Sunrise", "Dhuhr", "Asr", "Maghrib", "Isha", "Midnight"} {
+		row = append(row, times[name])
+	}
+	if iqama {
+		for _, name := range []string{"Fajr", "Dhuhr", "Asr", "Maghrib", "Isha"} {
+			iqama := ""
+			if e := day.Event(name); e != nil {
+				iqama = iqamaClock(e)
+			}
+			row = append(row, iqama)
+		}
+	}
+	return row
+}
+
+// hijriISO returns a Hijri date as YYYY-MM-DD, or "" when it is incomplete
+func hijriISO(year string, month int, day string) string {
+	y, errY := strconv.Atoi(year)
+	d, errD := strconv.Atoi(day)
+	if errY != nil || errD != nil || month == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", y, month, d)
+}
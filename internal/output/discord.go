@@ -3,9 +3,13 @@ package output
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 // DiscordFormatter formats output as Discord embed JSON
@@ -40,74 +44,73 @@ type DiscordFooter struct {
 
 // Format writes the prayer times as Discord embed JSON
 func (f *DiscordFormatter) Format(w io.Writer, data *PrayerData) error {
-	if data.Response == nil {
-		return fmt.Errorf("no prayer times data")
+	if data.Response == nil || data.Day == nil {
+		return errors.New(data.localizer().T("error.noData"))
 	}
 
-	resp := data.Response
-	timings := resp.Data.Timings
-	date := resp.Data.Date
-	meta := resp.Data.Meta
+	date := data.Response.Data.Date
+	l := data.localizer()
 
-	// Get current time for next prayer calculation
-	now := time.Now()
-	tz := meta.Timezone
-	if tz != "" {
-		loc, err := time.LoadLocation(tz)
-		if err == nil {
-			now = time.Now().In(loc)
+	// Prayers without Midnight, and the next one
+	var prayers []prayer.Event
+	for _, e := range data.Day.Events {
+		if e.Name != "Midnight" {
+			prayers = append(prayers, e)
 		}
 	}
-
-	prayers := []struct {
-		name string
-		time string
-	}{
-		{"Fajr", cleanTime(timings.Fajr)},
-		{"Sunrise", cleanTime(timings.Sunrise)},
-		{"Dhuhr", cleanTime(timings.Dhuhr)},
-		{"Asr", cleanTime(timings.Asr)},
-		{"Maghrib", cleanTime(timings.Maghrib)},
-		{"Isha", cleanTime(timings.Isha)},
-	}
-
-	// Find next prayer
-	nextPrayer := ""
-	for _, p := range prayers {
-		prayerTime, err := parseTimeToday(p.time, now)
-		if err != nil {
-			continue
-		}
-		if now.Before(prayerTime) {
-			nextPrayer = p.name
-			break
-		}
+	var nextPrayer time.Time
+	if next := data.Day.Next(data.now()); next != nil {
+		nextPrayer = next.Time
 	}
 
 	// Create fields
 	fields := make([]DiscordField, 0)
 	for _, p := range prayers {
-		value := p.time
-		if p.name == nextPrayer {
-			value = fmt.Sprintf("%s ▶️", p.time)
+		value := l.Time(p.Time)
+		if p.HasIqama() {
+			value = fmt.Sprintf("%s\n%s", value, l.T("label.iqama", l.Time(p.Iqama)))
+		}
+		if p.Time.Equal(nextPrayer) {
+			value = fmt.Sprintf("%s ▶️", value)
 		}
 		fields = append(fields, DiscordField{
-			Name:   p.name,
+			Name:   l.Prayer(p.Name),
 			Value:  value,
 			Inline: true,
 		})
 	}
 
+	if len(data.Reminders) > 0 {
+		fields = append(fields, DiscordField{
+			Name:   l.T("label.reminders"),
+			Value:  "• " + strings.Join(data.Reminders, "\n• "),
+			Inline: false,
+		})
+	}
+
+	if data.HasDua() {
+		fields = append(fields, DiscordField{
+			Name:   "📖 " + l.DuaTitle(data.Dua),
+			Value:  fmt.Sprintf("%s\n*%s*\n\"%s\"\n— %s", data.Dua.Arabic, data.Dua.Transliteration, data.Dua.Translation, duaSource(data.Dua)),
+			Inline: false,
+		})
+	}
+
+	footer := l.T("label.method", data.Method)
+	if data.Mosque != "" {
+		footer = fmt.Sprintf("%s • %s", footer, l.T("label.mosque", data.Mosque))
+	}
+
 	// Discord color (blue: 0x1DA1F2 = 1942002)
 	message := DiscordMessage{
 		Embeds: []DiscordEmbed{
 			{
-				Title:       "🕌 Prayer Times",
-				Description: fmt.Sprintf("**%s**\n%s", data.Location, date.Readable),
+				Title:       "🕌 " + l.T("heading.prayerTimes"),
+				Description: fmt.Sprintf("**%s**\n%s", data.Location, l.Date(date)),
 				Color:       1942002,
 				Fields:      fields,
 				Footer: &DiscordFooter{
-					Text: fmt.Sprintf("Method: %s", data.Method),
+					Text: footer,
 				},
 				Timestamp: time.Now().UTC().Format(time.RFC3339),
 			},
@@ -127,23 +130,34 @@ type WebhookOutput struct {
 	Date       DateOutput         `json:"date"`
 	Location   LocationOutput     `json:"location"`
 	Timings    TimingsOutput      `json:"timings"`
+	Iqama      *IqamaOutput       `json:"iqama,omitempty"`
 	NextPrayer *WebhookNextPrayer `json:"nextPrayer,omitempty"`
 	Qibla      *QiblaOutput       `json:"qibla,omitempty"`
-	ServerTime string             `json:"serverTime"`
+	Mosque     *MosqueOutput      `json:"mosque,omitempty"`
+	Jumuah     *JumuahOutput      `json:"jumuah,omitempty"`
+	Traveler   *TravelerOutput    `json:"traveler,omitempty"`
+	Reminders  []string           `json:"reminders,omitempty"`
+	Dua        *prayer.Dua        `json:"dua,omitempty"`
+
+	// Deprecated: ServerTime is the envelope's generatedAt, kept for webhooks that read it
+	// from before the envelope. It will be removed with the next schema version.
+	ServerTime string `json:"serverTime"`
 }
 
 // WebhookNextPrayer includes additional timestamp info
 type WebhookNextPrayer struct {
 	Name         string `json:"name"`
 	Time         string `json:"time"`
+	Tomorrow     bool   `json:"tomorrow,omitempty"`
+	Iqama        string `json:"iqama,omitempty"`
 	ISO          string `json:"iso"`
 	Timestamp    int64  `json:"timestamp"`
 	MinutesUntil int    `json:"minutesUntil"`
 }
 
-// Format writes the prayer times as detailed webhook JSON
+// Format writes the prayer times as detailed webhook JSON, in an Envelope
 func (f *WebhookFormatter) Format(w io.Writer, data *PrayerData) error {
-	if data.Response == nil {
+	if data.Response == nil || data.Day == nil {
 		return fmt.Errorf("no prayer times data")
 	}
 
@@ -152,20 +166,6 @@ func (f *WebhookFormatter) Format(w io.Writer, data *PrayerData) error {
 	date := resp.Data.Date
 	meta := resp.Data.Meta
 
-	now := time.Now()
-	tz := meta.Timezone
-	var loc *time.Location
-	if tz != "" {
-		var err error
-		loc, err = time.LoadLocation(tz)
-		if err == nil {
-			now = time.Now().In(loc)
-		}
-	}
-	if loc == nil {
-		loc = time.Local
-	}
-
 	output := WebhookOutput{
 		Date: DateOutput{
 			Gregorian: date.Readable,
@@ -185,7 +185,15 @@ func (f *WebhookFormatter) Format(w io.Writer, data *PrayerData) error {
 			Isha:     cleanTime(timings.Isha),
 			Midnight: cleanTime(timings.Midnight),
 		},
-		ServerTime: time.Now().UTC().Format(time.RFC3339),
+		Iqama:     buildIqamaOutput(data),
+		Mosque:    buildMosqueOutput(data),
+		Jumuah:    buildJumuahOutput(data),
+		Traveler:  buildTravelerOutput(data),
+		Reminders: data.Reminders,
+	}
+
+	if data.HasDua() {
+		output.Dua = data.Dua
 	}
 
 	// Add Hijri date
@@ -202,35 +210,17 @@ func (f *WebhookFormatter) Format(w io.Writer, data *PrayerData) error {
 		}
 	}
 
-	// Calculate next prayer with full details
-	prayers := []struct {
-		name string
-		time string
-	}{
-		{"Fajr", cleanTime(timings.Fajr)},
-		{"Sunrise", cleanTime(timings.Sunrise)},
-		{"Dhuhr", cleanTime(timings.Dhuhr)},
-		{"Asr", cleanTime(timings.Asr)},
-		{"Maghrib", cleanTime(timings.Maghrib)},
-		{"Isha", cleanTime(timings.Isha)},
-		{"Midnight", cleanTime(timings.Midnight)},
-	}
-
-	for _, p := range prayers {
-		prayerTime, err := parseTimeToday(p.time, now)
-		if err != nil {
-			continue
-		}
-		if now.Before(prayerTime) {
-			mins := int(time.Until(prayerTime).Minutes())
-			output.NextPrayer = &WebhookNextPrayer{
-				Name:         p.name,
-				Time:         p.time,
-				ISO:          prayerTime.UTC().Format(time.RFC3339),
-				Timestamp:    prayerTime.Unix(),
-				MinutesUntil: mins,
-			}
-			break
+	// Next prayer with full details
+	now := data.now()
+	if next := data.Day.NextPrayer(now); next != nil {
+		output.NextPrayer = &WebhookNextPrayer{
+			Name:         next.Name,
+			Time:         next.Clock(),
+			Tomorrow:     data.Day.IsLaterDate(next.Time),
+			Iqama:        iqamaClock(next),
+			ISO:          next.Time.UTC().Format(time.RFC3339),
+			Timestamp:    next.Time.Unix(),
+			MinutesUntil: int(next.Time.Sub(now).Minutes()),
 		}
 	}
 
@@ -238,11 +228,14 @@ func (f *WebhookFormatter) Format(w io.Writer, data *PrayerData) error {
 	if data.ShowQibla && data.Qibla != nil {
 		output.Qibla = &QiblaOutput{
 			Direction: data.Qibla.Direction,
-			Compass:   getCompassDirection(data.Qibla.Direction),
+			Compass:   CompassDirection(data.Qibla.Direction),
 		}
 	}
 
+	envelope := NewEnvelope(&output)
+	output.ServerTime = envelope.GeneratedAt
+
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return encoder.Encode(envelope)
 }
@@ -2,9 +2,13 @@
 package output
 
 import (
+	"fmt"
 	"io"
+	"slices"
+	"time"
 
 	"github.com/AbdElrahmaN31/pray-cli/internal/api"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 // Formatter is the interface for output formatters
@@ -12,19 +16,139 @@ type Formatter interface {
 	Format(w io.Writer, data *PrayerData) error
 }
 
+// RangeFormatter is implemented by formatters that can show several days at once
+type RangeFormatter interface {
+	FormatRange(w io.Writer, days []*PrayerData) error
+}
+
 // PrayerData contains all the data needed for formatting
 type PrayerData struct {
-	Response    *api.PrayerTimesResponse
-	Location    string
-	Method      string
-	NextPrayer  *api.NextPrayer
-	Qibla       *api.QiblaData
-	ShowQibla   bool
-	ShowDua     bool
-	ShowHijri   bool
-	HijriFormat string // "title", "desc", "both", "none"
-	Language    string
-	NoColor     bool
+	Response       *api.PrayerTimesResponse
+	Day            *prayer.Day // Normalized day with Iqama, Jumu'ah and traveler settings applied, see NewDay
+	Location       string
+	Method         string
+	NextPrayer     *api.NextPrayer
+	Qibla          *api.QiblaData
+	Mosque         string      // Name of the active mosque profile, if any
+	JumuahDuration int         // Jumu'ah duration in minutes
+	Reminders      []string    // Day-specific reminders (e.g., Friday Surah al-Kahf)
+	Dua            *prayer.Dua // Du'a or dhikr for the day, shown when ShowDua is set
+	ShowQibla      bool
+	ShowDua        bool
+	ShowHijri      bool
+	HijriFormat    string // "title", "desc", "both", "none"
+	Language       string
+	NativeDigits   bool   // Render numbers with the language's native digits (e.g., Eastern Arabic)
+	TimeFormat     string // Clock for displayed times: "24h", "12h" or "locale"
+	Theme          *Theme // Icons and box drawing, nil for the emoji theme
+	Template       string // Go text/template for the template format, see TemplateData
+	Delimiter      string // CSV field delimiter, see ParseDelimiter
+	DateFormat     string // Go layout of dates in CSV and TSV output, DefaultDateFormat when empty
+	HTMLTheme      string // Color theme of HTML output, see HTMLThemes
+	NoColor        bool
+	Now            time.Time // Time the output is rendered for, the current time when zero
+}
+
+// localizer returns the Localizer for the data's language
+func (d *PrayerData) localizer() *Localizer {
+	return NewLocalizer(d.Language, d.NativeDigits).WithTimeFormat(d.TimeFormat)
+}
+
+// NewDay builds the normalized prayer day from an API response, in the response's timezone
+func NewDay(resp *api.PrayerTimesResponse) *prayer.Day {
+	meta := resp.Data.Meta
+	loc := time.Local
+	if l, err := time.LoadLocation(meta.Timezone); err == nil {
+		loc = l
+	}
+
+	date := time.Now().In(loc)
+	if t, err := time.ParseInLocation("02-01-2006", resp.Data.Date.Gregorian.Date, loc); err == nil {
+		date = t
+	} else if t, err := time.ParseInLocation("02 Jan 2006", resp.Data.Date.Readable, loc); err == nil {
+		date = t
+	}
+
+	day := prayer.NewDay(date, AdhanTimes(resp.Data.Timings))
+	day.Latitude = meta.Latitude
+	day.Longitude = meta.Longitude
+	day.Method = meta.Method.Name
+
+	hijri := resp.Data.Date.Hijri
+	day.Hijri = prayer.HijriDate{
+		Day:     hijri.Day,
+		Month:   hijri.Month.Number,
+		MonthEn: hijri.Month.En,
+		MonthAr: hijri.Month.Ar,
+		Year:    hijri.Year,
+	}
+	return day
+}
+
+// now returns the time the output is rendered for in the day's timezone
+func (d *PrayerData) now() time.Time {
+	if !d.Now.IsZero() {
+		return d.Now.In(d.Day.Date.Location())
+	}
+	return time.Now().In(d.Day.Date.Location())
+}
+
+// nextTomorrow returns the line announcing the next prayer when it falls on the following
+// day (e.g., Fajr after Isha), or "" when it is one of the day's own prayers
+func (d *PrayerData) nextTomorrow(l *Localizer, now time.Time) string {
+	next := d.Day.Next(now)
+	if next == nil || !d.Day.IsLaterDate(next.Time) {
+		return ""
+	}
+	return l.T("status.nextTomorrow", l.Prayer(next.Name), l.Time(next.Time), l.Duration(int(next.Time.Sub(now).Minutes())))
+}
+
+// theme returns the theme to render with, defaulting to emoji
+func (d *PrayerData) theme() *Theme {
+	if d.Theme == nil {
+		return ThemeEmoji
+	}
+	return d.Theme
+}
+
+// HasIqama reports whether iqama times should be displayed
+func (d *PrayerData) HasIqama() bool {
+	return d.Day != nil && d.Day.HasIqama()
+}
+
+// HasDua reports whether a du'a should be displayed
+func (d *PrayerData) HasDua() bool {
+	return d.ShowDua && d.Dua != nil
+}
+
+// HasJumuah reports whether Dhuhr is replaced with Jumu'ah
+func (d *PrayerData) HasJumuah() bool {
+	return d.Day != nil && len(d.Day.Jumuah()) > 0
+}
+
+// AdhanTimes returns the cleaned adhan times of the API timings keyed by event name
+func AdhanTimes(timings api.Timings) map[string]string {
+	return map[string]string{
+		"Fajr":     cleanTime(timings.Fajr),
+		"Sunrise":  cleanTime(timings.Sunrise),
+		"Dhuhr":    cleanTime(timings.Dhuhr),
+		"Asr":      cleanTime(timings.Asr),
+		"Maghrib":  cleanTime(timings.Maghrib),
+		"Isha":     cleanTime(timings.Isha),
+		"Midnight": cleanTime(timings.Midnight),
+	}
+}
+
+// rakahLabel returns the traveler rak'ah count for a prayer (e.g., "2 (qasr)"), or "" for other events
+func rakahLabel(l *Localizer, name string) string {
+	count := prayer.RakahCount(name, true)
+	if count == 0 {
+		return ""
+	}
+	if prayer.IsShortened(name) {
+		return l.Digits(fmt.Sprintf("%d ", count)) + l.T("label.qasr")
+	}
+	return l.Digits(fmt.Sprintf("%d", count))
 }
 
 // GetFormatter returns the appropriate formatter for the given format
@@ -34,18 +158,51 @@ func GetFormatter(format string) Formatter {
 		return &PrettyFormatter{}
 	case "json":
 		return &JSONFormatter{}
+	case "ndjson":
+		return &NDJSONFormatter{}
+	case "waybar":
+		return &WaybarFormatter{}
+	case "i3bar":
+		return &I3barFormatter{}
+	case "polybar":
+		return &PolybarFormatter{}
+	case "tmux":
+		return &TmuxFormatter{}
+	case "yaml":
+		return &YAMLFormatter{}
+	case "xml":
+		return &XMLFormatter{}
 	case "slack":
 		return &SlackFormatter{}
 	case "discord":
 		return &DiscordFormatter{}
 	case "webhook":
 		return &WebhookFormatter{}
+	case "template":
+		return &TemplateFormatter{}
+	case "csv":
+		return &CSVFormatter{}
+	case "tsv":
+		return &TSVFormatter{}
+	case "markdown":
+		return &MarkdownFormatter{}
+	case "html":
+		return &HTMLFormatter{}
 	default:
 		return &TableFormatter{}
 	}
 }
 
+// MachineFormats lists the formats meant to be read by other programs, which must not
+// be followed by notices such as the update message
+var MachineFormats = []string{"json", "ndjson", "yaml", "xml"}
+
+// IsMachineFormat reports whether format is read by other programs
+func IsMachineFormat(format string) bool {
+	return slices.Contains(MachineFormats, format)
+}
+
 // FormatTypes returns all available format types
 func FormatTypes() []string {
-	return []string{"table", "pretty", "json", "slack", "discord", "webhook"}
+	return []string{"table", "pretty", "json", "slack", "discord", "webhook", "template", "csv", "tsv", "markdown", "html", "yaml", "xml", "ndjson", "waybar", "i3bar", "polybar", "tmux"}
 }
@@ -2,10 +2,20 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
 
 	"github.com/AbdElrahmaN31/pray-cli/internal/api"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 func TestGetFormatter(t *testing.T) {
@@ -19,6 +29,18 @@ func TestGetFormatter(t *testing.T) {
 		{"slack", "*output.SlackFormatter"},
 		{"discord", "*output.DiscordFormatter"},
 		{"webhook", "*output.WebhookFormatter"},
+		{"template", "*output.TemplateFormatter"},
+		{"csv", "*output.CSVFormatter"},
+		{"tsv", "*output.TSVFormatter"},
+		{"markdown", "*output.MarkdownFormatter"},
+		{"html", "*output.HTMLFormatter"},
+		{"yaml", "*output.YAMLFormatter"},
+		{"xml", "*output.XMLFormatter"},
+		{"ndjson", "*output.NDJSONFormatter"},
+		{"waybar", "*output.WaybarFormatter"},
+		{"i3bar", "*output.I3barFormatter"},
+		{"polybar", "*output.PolybarFormatter"},
+		{"tmux", "*output.TmuxFormatter"},
 		{"unknown", "*output.TableFormatter"}, // Default
 		{"", "*output.TableFormatter"},        // Empty default
 	}
@@ -36,7 +58,7 @@ func TestGetFormatter(t *testing.T) {
 func TestFormatTypes(t *testing.T) {
 	types := FormatTypes()
 
-	expected := []string{"table", "pretty", "json", "slack", "discord", "webhook"}
+	expected := []string{"table", "pretty", "json", "slack", "discord", "webhook", "template", "csv", "tsv", "markdown", "html", "yaml", "xml", "ndjson", "waybar", "i3bar", "polybar", "tmux"}
 
 	if len(types) != len(expected) {
 		t.Errorf("FormatTypes() returned %d types, want %d", len(types), len(expected))
@@ -57,7 +79,7 @@ func TestFormatTypes(t *testing.T) {
 }
 
 func createTestPrayerData() *PrayerData {
-	return &PrayerData{
+	return withDay(&PrayerData{
 		Response: &api.PrayerTimesResponse{
 			Code:   200,
 			Status: "OK",
@@ -100,7 +122,15 @@ func createTestPrayerData() *PrayerData {
 		HijriFormat: "desc",
 		Language:    "en",
 		NoColor:     true,
-	}
+	})
+}
+
+// withDay builds the data's normalized day from its response, as the commands do
+func withDay(data *PrayerData) *PrayerData {
+	data.Day = NewDay(data.Response)
+	data.Day.Location = data.Location
+	data.Day.Method = data.Method
+	return data
 }
 
 func TestJSONFormatter(t *testing.T) {
@@ -134,6 +164,34 @@ func TestJSONFormatter(t *testing.T) {
 	}
 }
 
+func TestJSONFormatterIqama(t *testing.T) {
+	data := createTestPrayerData()
+	data.Day.SetIqama(map[string]string{"Fajr": "05:30", "Isha": "20:30"})
+
+	var buf bytes.Buffer
+	if err := (&JSONFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("JSONFormatter.Format() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"iqama"`) {
+		t.Error("JSON output missing 'iqama' object")
+	}
+	if !strings.Contains(output, `"Isha": "20:30"`) {
+		t.Error("JSON output missing Isha iqama time")
+	}
+
+	// Without iqama, the object is omitted
+	buf.Reset()
+	withDay(data)
+	if err := (&JSONFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("JSONFormatter.Format() error = %v", err)
+	}
+	if strings.Contains(buf.String(), `"iqama"`) {
+		t.Error("JSON output should omit 'iqama' when disabled")
+	}
+}
+
 func TestSlackFormatter(t *testing.T) {
 	data := createTestPrayerData()
 
@@ -198,8 +256,22 @@ func TestWebhookFormatter(t *testing.T) {
 	output := buf.String()
 
 	// Check webhook format
-	if !strings.Contains(output, `"serverTime"`) {
-		t.Error("Webhook output missing 'serverTime' field")
+	if !strings.Contains(output, `"generatedAt"`) {
+		t.Error("Webhook output missing 'generatedAt' field")
+	}
+
+	// serverTime is kept as a deprecated alias of generatedAt
+	var payload struct {
+		GeneratedAt string `json:"generatedAt"`
+		Data        struct {
+			ServerTime string `json:"serverTime"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("webhook output is not JSON: %v", err)
+	}
+	if payload.Data.ServerTime == "" || payload.Data.ServerTime != payload.GeneratedAt {
+		t.Errorf("serverTime = %q, want generatedAt %q", payload.Data.ServerTime, payload.GeneratedAt)
 	}
 
 	if !strings.Contains(output, `"timings"`) {
@@ -207,6 +279,58 @@ func TestWebhookFormatter(t *testing.T) {
 	}
 }
 
+// flushRecorder records the writes and flushes of a buffered writer
+type flushRecorder struct {
+	bytes.Buffer
+	events []string
+}
+
+func (r *flushRecorder) Write(p []byte) (int, error) {
+	r.events = append(r.events, "write")
+	return r.Buffer.Write(p)
+}
+
+func (r *flushRecorder) Flush() error {
+	r.events = append(r.events, "flush")
+	return nil
+}
+
+func TestNDJSONFormatter(t *testing.T) {
+	first := createTestPrayerData()
+	second := createTestPrayerData()
+	second.Response.Data.Date.Gregorian.Date = "05-02-2026"
+	second.Response.Data.Timings.Fajr = "05:14"
+
+	var w flushRecorder
+	if err := (&NDJSONFormatter{}).FormatRange(&w, []*PrayerData{first, second}); err != nil {
+		t.Fatalf("FormatRange() error = %v", err)
+	}
+	if want := []string{"write", "flush", "write", "flush"}; !slices.Equal(w.events, want) {
+		t.Errorf("writes = %v, want a line and a flush per day %v", w.events, want)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("FormatRange() wrote %d lines, want 2:\n%s", len(lines), w.String())
+	}
+	for i, wantFajr := range []string{"05:15", "05:14"} {
+		var line struct {
+			SchemaVersion int `json:"schemaVersion"`
+			Data          struct {
+				Timings struct {
+					Fajr string `json:"Fajr"`
+				} `json:"timings"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(lines[i]), &line); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", i+1, err)
+		}
+		if line.SchemaVersion != SchemaVersion || line.Data.Timings.Fajr != wantFajr {
+			t.Errorf("line %d = %s, want Fajr %s in an envelope", i+1, lines[i], wantFajr)
+		}
+	}
+}
+
 func TestFormatWithNilResponse(t *testing.T) {
 	data := &PrayerData{
 		Response: nil,
@@ -222,6 +346,18 @@ func TestFormatWithNilResponse(t *testing.T) {
 		{"slack", &SlackFormatter{}},
 		{"discord", &DiscordFormatter{}},
 		{"webhook", &WebhookFormatter{}},
+		{"template", &TemplateFormatter{}},
+		{"csv", &CSVFormatter{}},
+		{"tsv", &TSVFormatter{}},
+		{"markdown", &MarkdownFormatter{}},
+		{"html", &HTMLFormatter{}},
+		{"yaml", &YAMLFormatter{}},
+		{"xml", &XMLFormatter{}},
+		{"ndjson", &NDJSONFormatter{}},
+		{"waybar", &WaybarFormatter{}},
+		{"i3bar", &I3barFormatter{}},
+		{"polybar", &PolybarFormatter{}},
+		{"tmux", &TmuxFormatter{}},
 	}
 
 	for _, f := range formatters {
@@ -256,7 +392,7 @@ func TestCleanTime(t *testing.T) {
 	}
 }
 
-func TestGetCompassDirection(t *testing.T) {
+func TestCompassDirection(t *testing.T) {
 	tests := []struct {
 		degrees float64
 		want    string
@@ -275,9 +411,9 @@ func TestGetCompassDirection(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
-			got := getCompassDirection(tt.degrees)
+			got := CompassDirection(tt.degrees)
 			if got != tt.want {
-				t.Errorf("getCompassDirection(%f) = %s, want %s", tt.degrees, got, tt.want)
+				t.Errorf("CompassDirection(%f) = %s, want %s", tt.degrees, got, tt.want)
 			}
 		})
 	}
@@ -304,3 +440,877 @@ func TestFormatMinutes(t *testing.T) {
 		})
 	}
 }
+
+func TestDayJumuah(t *testing.T) {
+	data := createTestPrayerData()
+	data.Day.SetJumuah([]string{"13:00", "14:15"})
+	data.JumuahDuration = 45
+
+	events := data.Day.Events
+	want := []string{"Fajr", "Sunrise", "Jumu'ah", "Jumu'ah 2", "Asr", "Maghrib", "Isha", "Midnight"}
+	if len(events) != len(want) {
+		t.Fatalf("Day has %d events, want %d", len(events), len(want))
+	}
+	for i, name := range want {
+		if events[i].Name != name {
+			t.Errorf("event %d = %s, want %s", i, events[i].Name, name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := (&JSONFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("JSONFormatter.Format() error = %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, `"jumuah"`) || !strings.Contains(output, `"14:15"`) {
+		t.Error("JSON output missing 'jumuah' entry")
+	}
+	if !strings.Contains(output, `"duration": 45`) {
+		t.Error("JSON output missing Jumu'ah duration")
+	}
+}
+
+func TestFormattersNextTomorrow(t *testing.T) {
+	// A day whose prayers have all passed, followed by one whose Fajr is an hour away
+	loc, _ := time.LoadLocation("Africa/Cairo")
+	now := time.Date(2026, time.February, 4, 23, 0, 0, 0, loc)
+	fajr := now.Add(time.Hour)
+	data := createTestPrayerData()
+	data.Now = now
+	data.Day = prayer.NewDay(fajr.AddDate(0, 0, -1), map[string]string{"Fajr": "00:00", "Isha": "00:01"})
+	data.Day.Following = prayer.NewDay(fajr, map[string]string{"Fajr": fajr.Format("15:04")})
+
+	var buf bytes.Buffer
+	if err := (&JSONFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("JSONFormatter.Format() error = %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, `"tomorrow": true`) || !strings.Contains(output, `"time": "`+fajr.Format("15:04")+`"`) {
+		t.Errorf("JSON next prayer should be tomorrow's Fajr:\n%s", output)
+	}
+
+	for _, f := range []Formatter{&PrettyFormatter{}, &TableFormatter{}} {
+		buf.Reset()
+		if err := f.Format(&buf, data); err != nil {
+			t.Fatalf("%T.Format() error = %v", f, err)
+		}
+		if !strings.Contains(buf.String(), "Next prayer: Fajr tomorrow at "+fajr.Format("15:04")) {
+			t.Errorf("%T output missing tomorrow's Fajr:\n%s", f, buf.String())
+		}
+	}
+}
+
+func TestStatusFormatters(t *testing.T) {
+	loc, _ := time.LoadLocation("Africa/Cairo")
+	now := time.Date(2026, time.February, 4, 14, 0, 0, 0, loc)
+	statusData := func(until time.Duration) *PrayerData {
+		data := createTestPrayerData()
+		data.Now = now
+		maghrib := now.Add(until)
+		data.Day = prayer.NewDay(maghrib, map[string]string{"Maghrib": maghrib.Format("15:04")})
+		data.Day.SetIqama(map[string]string{"Maghrib": maghrib.Add(10 * time.Minute).Format("15:04")})
+		return data
+	}
+
+	tests := []struct {
+		until   time.Duration
+		urgency string
+	}{
+		{91 * time.Minute, "normal"},
+		{11 * time.Minute, "warning"},
+		{4 * time.Minute, "critical"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.urgency, func(t *testing.T) {
+			data := statusData(tt.until)
+			clock := now.Add(tt.until).Format("15:04")
+
+			var buf bytes.Buffer
+			if err := (&WaybarFormatter{}).Format(&buf, data); err != nil {
+				t.Fatalf("WaybarFormatter.Format() error = %v", err)
+			}
+			var waybar struct {
+				Text    string `json:"text"`
+				Alt     string `json:"alt"`
+				Tooltip string `json:"tooltip"`
+				Class   string `json:"class"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &waybar); err != nil {
+				t.Fatalf("waybar output is not JSON: %v\n%s", err, buf.String())
+			}
+			if !strings.HasPrefix(waybar.Text, "Maghrib "+clock+" (") || waybar.Alt != "maghrib" || waybar.Class != tt.urgency {
+				t.Errorf("waybar output = %+v, want Maghrib at %s, %s", waybar, clock, tt.urgency)
+			}
+			if !strings.Contains(waybar.Tooltip, "▸ Maghrib  "+clock+"  Iqama ") || !strings.HasSuffix(waybar.Tooltip, "Cairo, Egypt") {
+				t.Errorf("waybar tooltip = %q", waybar.Tooltip)
+			}
+
+			buf.Reset()
+			if err := (&I3barFormatter{}).Format(&buf, data); err != nil {
+				t.Fatalf("I3barFormatter.Format() error = %v", err)
+			}
+			if urgent := strings.Contains(buf.String(), `"urgent":true`); urgent != (tt.urgency == "critical") {
+				t.Errorf("i3bar block = %s, urgent should be %v", buf.String(), tt.urgency == "critical")
+			}
+
+			// Colors are only added near the prayer, and not with --no-color
+			data.NoColor = false
+			for _, f := range []Formatter{&PolybarFormatter{}, &TmuxFormatter{}} {
+				buf.Reset()
+				if err := f.Format(&buf, data); err != nil {
+					t.Fatalf("%T.Format() error = %v", f, err)
+				}
+				colored := strings.Contains(buf.String(), statusWarningColor) || strings.Contains(buf.String(), statusCriticalColor)
+				if colored != (tt.urgency != "normal") || !strings.Contains(buf.String(), "Maghrib "+clock) || strings.Count(buf.String(), "\n") != 1 {
+					t.Errorf("%T output = %q", f, buf.String())
+				}
+			}
+		})
+	}
+
+	for _, format := range StatusFormats {
+		if !IsStatusFormat(format) || !slices.Contains(FormatTypes(), format) {
+			t.Errorf("%s should be a status format in FormatTypes()", format)
+		}
+	}
+	if IsStatusFormat("json") {
+		t.Error("json is not a status format")
+	}
+}
+
+func TestIsMachineFormat(t *testing.T) {
+	for _, format := range MachineFormats {
+		if !IsMachineFormat(format) || !slices.Contains(FormatTypes(), format) {
+			t.Errorf("%s should be a machine format in FormatTypes()", format)
+		}
+	}
+	for _, format := range []string{"table", "pretty", "template", "waybar"} {
+		if IsMachineFormat(format) {
+			t.Errorf("%s is not a machine format", format)
+		}
+	}
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	// Maghrib is 90 minutes away, the earlier prayers have passed
+	loc, _ := time.LoadLocation("Africa/Cairo")
+	now := time.Date(2026, time.February, 4, 14, 0, 30, 0, loc)
+	maghrib := now.Truncate(time.Minute).Add(91 * time.Minute)
+	data := createTestPrayerData()
+	data.Now = now
+	data.Day = prayer.NewDay(now, map[string]string{
+		"Fajr":    "00:30",
+		"Maghrib": maghrib.Format("15:04"),
+	})
+	data.Day.Hijri = prayer.HijriDate{Day: "16", Month: 8, MonthEn: "Sha'ban", Year: "1447"}
+	data.Day.SetIqama(map[string]string{"Maghrib": maghrib.Add(5 * time.Minute).Format("15:04")})
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"next", "{{.Next.Name}} in {{.Next.In}}", "Maghrib in 1h 30m\n"},
+		{"clock", "{{clock .Next.Time}}|{{clock .Next.Iqama}}", maghrib.Format("15:04") + "|" + maghrib.Add(5*time.Minute).Format("15:04") + "\n"},
+		{"duration", "{{duration .Next.Until}} {{minutes .Next.Until}}", "1h 30m 90\n"},
+		{"short", "{{short .Next.Until}}", "1h30m\n"},
+		{"hijri", "{{hijri .Hijri}}", "16 Sha'ban 1447\n"},
+		{"padding", "[{{pad 8 .Next.Name}}][{{padLeft 4 \"ab\"}}]", "[Maghrib ][  ab]\n"},
+		{"lookup", "{{with .Prayer \"fajr\"}}{{.Passed}} {{.IsNext}}{{end}}", "true false\n"},
+		{"range", "{{range .Prayers}}{{if not .Passed}}{{.Name}}{{end}}{{end}}", "Maghrib\n"},
+		{"format", "{{format \"2006-01-02\" .Date}}", now.Format("2006-01-02") + "\n"},
+		{"newline kept", "{{upper .Next.Name}}\n", "MAGHRIB\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data.Template = tt.template
+			var buf bytes.Buffer
+			if err := (&TemplateFormatter{}).Format(&buf, data); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Format() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+
+	for _, tmpl := range []string{"", "{{.Next.Name", "{{.Missing}}"} {
+		data.Template = tmpl
+		if err := (&TemplateFormatter{}).Format(&bytes.Buffer{}, data); err == nil {
+			t.Errorf("Format() with template %q should return an error", tmpl)
+		}
+	}
+}
+
+func TestCSVFormatter(t *testing.T) {
+	first := createTestPrayerData()
+	first.Response.Data.Date.Gregorian.Date = "04-02-2026"
+	second := createTestPrayerData()
+	second.Response.Data.Date.Gregorian.Date = "05-02-2026"
+	second.Response.Data.Date.Hijri.Day = "17"
+	second.Response.Data.Timings.Fajr = "05:14"
+	withDay(first)
+	withDay(second)
+
+	var buf bytes.Buffer
+	if err := (&CSVFormatter{}).FormatRange(&buf, []*PrayerData{first, second}); err != nil {
+		t.Fatalf("FormatRange() error = %v", err)
+	}
+	want := "date,hijri,fajr,sunrise,dhuhr,asr,maghrib,isha,midnight\n" +
+		"2026-02-04,1447-08-16,05:15,06:44,12:09,15:12,17:34,18:54,00:09\n" +
+		"2026-02-05,1447-08-17,05:14,06:44,12:09,15:12,17:34,18:54,00:09\n"
+	if buf.String() != want {
+		t.Errorf("CSV range =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// Iqama columns, delimiter, date format and disabled Hijri
+	first.Day.SetIqama(map[string]string{"Fajr": "05:35", "Isha": "20:30"})
+	first.Delimiter = ";"
+	first.DateFormat = "02/01/2006"
+	first.HijriFormat = "none"
+	buf.Reset()
+	if err := (&CSVFormatter{}).Format(&buf, first); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	want = "date;hijri;fajr;sunrise;dhuhr;asr;maghrib;isha;midnight;fajr_iqama;dhuhr_iqama;asr_iqama;maghrib_iqama;isha_iqama\n" +
+		"04/02/2026;;05:15;06:44;12:09;15:12;17:34;18:54;00:09;05:35;;;;20:30\n"
+	if buf.String() != want {
+		t.Errorf("CSV with iqama =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// TSV always uses tabs
+	buf.Reset()
+	if err := (&TSVFormatter{}).Format(&buf, second); err != nil {
+		t.Fatalf("TSV Format() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "date\thijri\tfajr\t") || !strings.Contains(buf.String(), "2026-02-05\t1447-08-17\t05:14\t") {
+		t.Errorf("TSV output = %q", buf.String())
+	}
+
+	first.Delimiter = "::"
+	if err := (&CSVFormatter{}).Format(&bytes.Buffer{}, first); err == nil {
+		t.Error("Format() with an invalid delimiter should return an error")
+	}
+}
+
+func TestMarkdownFormatter(t *testing.T) {
+	data := createTestPrayerData()
+	data.Response.Data.Date.Gregorian.Date = "04-02-2026"
+	withDay(data).Day.SetIqama(map[string]string{"Fajr": "05:35"})
+
+	var buf bytes.Buffer
+	if err := (&MarkdownFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"## Prayer Times - Cairo, Egypt\n",
+		"**Wednesday, 4 February 2026 · 16 Sha'ban 1447**",
+		"| Prayer | Time | Iqama |\n| --- | --- | --- |\n",
+		"| Fajr | 05:15 | 05:35 |\n",
+		"| Sunrise | 06:44 |  |\n",
+		"Method: Egyptian General Authority of Survey",
+		"_Generated ",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Markdown output missing %q:\n%s", want, output)
+		}
+	}
+
+	// A range has a row per day with the Iqama time after the Adhan time
+	second := createTestPrayerData()
+	second.Response.Data.Date.Gregorian.Date = "05-02-2026"
+	second.Location = "Cairo | Giza"
+	withDay(second)
+	data.Location = second.Location
+	withDay(data).Day.SetIqama(map[string]string{"Fajr": "05:35"})
+	buf.Reset()
+	if err := (&MarkdownFormatter{}).FormatRange(&buf, []*PrayerData{data, second}); err != nil {
+		t.Fatalf("FormatRange() error = %v", err)
+	}
+	output = buf.String()
+	for _, want := range []string{
+		"| Date | Hijri | Fajr | Sunrise | Dhuhr | Asr | Maghrib | Isha |\n",
+		"| Wednesday, 4 February 2026 | 16 Sha'ban 1447 | 05:15 (05:35) | 06:44 |",
+		"| Thursday, 5 February 2026 | 16 Sha'ban 1447 | 05:15 | 06:44 |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Markdown range missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestHTMLFormatter(t *testing.T) {
+	data := createTestPrayerData()
+	data.Location = "Cairo <Egypt>"
+	data.Mosque = "Masjid & Co"
+
+	var buf bytes.Buffer
+	if err := (&HTMLFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`<html lang="en" dir="ltr" class="light">`,
+		"<h1>Prayer Times - Cairo &lt;Egypt&gt;</h1>",
+		"<td>Fajr</td><td>05:15</td>",
+		"<p>Mosque: Masjid &amp; Co</p>",
+		"<p>Method: Egyptian General Authority of Survey</p>",
+		"@media print",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("HTML output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "<link") || strings.Contains(output, "<script") {
+		t.Error("HTML output should be self-contained")
+	}
+
+	data.HTMLTheme = "dark"
+	data.Language = "ar"
+	withDay(data).Day.SetIqama(map[string]string{"Isha": "20:30"})
+	buf.Reset()
+	if err := (&HTMLFormatter{}).FormatRange(&buf, []*PrayerData{data, createTestPrayerData()}); err != nil {
+		t.Fatalf("FormatRange() error = %v", err)
+	}
+	output = buf.String()
+	if !strings.Contains(output, `<html lang="ar" dir="rtl" class="dark">`) {
+		t.Errorf("HTML range should be dark and right-to-left:\n%s", output)
+	}
+	if !strings.Contains(output, "<td>18:54<small>20:30</small></td>") {
+		t.Errorf("HTML range should show the Iqama time below the Adhan time:\n%s", output)
+	}
+}
+
+func TestParseDelimiter(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    rune
+		wantErr bool
+	}{
+		{"", ',', false},
+		{",", ',', false},
+		{";", ';', false},
+		{"tab", '\t', false},
+		{`\t`, '\t', false},
+		{"|", '|', false},
+		{";;", 0, true},
+		{`"`, 0, true},
+		{"\n", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDelimiter(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDelimiter(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestJSONFormatterTraveler(t *testing.T) {
+	data := createTestPrayerData()
+	data.Day.Traveler = true
+
+	var buf bytes.Buffer
+	if err := (&JSONFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("JSONFormatter.Format() error = %v", err)
+	}
+
+	output := buf.String()
+	expected := []string{`"traveler"`, `"Dhuhr + Asr"`, `"Maghrib + Isha"`, `"taqdim": "12:09"`, `"Isha": 2`}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("JSON output missing %s", want)
+		}
+	}
+
+	// The next prayer is the combined one, as pray next shows it, in JSON and templates
+	data.Now = data.Day.Date.Add(13 * time.Hour)
+	buf.Reset()
+	if err := (&JSONFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("JSONFormatter.Format() error = %v", err)
+	}
+	var out struct {
+		Data struct {
+			NextPrayer struct{ Name string } `json:"nextPrayer"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out.Data.NextPrayer.Name != "Maghrib + Isha" {
+		t.Errorf("traveler nextPrayer = %q, want Maghrib + Isha", out.Data.NextPrayer.Name)
+	}
+	data.Template = "{{.Next.Name}}"
+	buf.Reset()
+	if err := (&TemplateFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("TemplateFormatter.Format() error = %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "Maghrib + Isha" {
+		t.Errorf("traveler template .Next = %q, want Maghrib + Isha", got)
+	}
+}
+
+// TestStructuredFormatsParity checks that the YAML and XML formats have the same fields
+// and values as the JSON format
+func TestStructuredFormatsParity(t *testing.T) {
+	data := createFullPrayerData()
+
+	format := func(f Formatter) []byte {
+		var buf bytes.Buffer
+		if err := f.Format(&buf, data); err != nil {
+			t.Fatalf("%T.Format() error = %v", f, err)
+		}
+		return buf.Bytes()
+	}
+
+	var fromJSON, fromYAML any
+	if err := json.Unmarshal(format(&JSONFormatter{}), &fromJSON); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if err := yaml.Unmarshal(format(&YAMLFormatter{}), &fromYAML); err != nil {
+		t.Fatalf("invalid YAML: %v", err)
+	}
+	fromXML, err := xmlLeaves(format(&XMLFormatter{}))
+	if err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+
+	want := treeLeaves(fromJSON)
+	for _, path := range []string{"schemaVersion", "source/version", "data/iqama/Isha", "data/mosque/name", "data/jumuah/times", "data/traveler/rakahs/Maghrib", "data/traveler/combined/prayers", "data/reminders", "data/dua/arabic", "data/qibla/compass"} {
+		if _, ok := want[path]; !ok {
+			t.Fatalf("JSON output is missing %s, the test data should cover it", path)
+		}
+	}
+	for name, got := range map[string]map[string][]string{"YAML": treeLeaves(fromYAML), "XML": fromXML} {
+		for path, values := range want {
+			if path == "generatedAt" {
+				continue // May be a second later
+			}
+			if !slices.Equal(got[path], values) {
+				t.Errorf("%s %s = %q, JSON has %q", name, path, got[path], values)
+			}
+		}
+		for path := range got {
+			if _, ok := want[path]; !ok {
+				t.Errorf("%s has %s, which JSON does not", name, path)
+			}
+		}
+	}
+}
+
+// createFullPrayerData creates test data with every optional section of the structured
+// formats enabled
+func createFullPrayerData() *PrayerData {
+	data := createTestPrayerData()
+	data.Day.SetIqama(map[string]string{"Fajr": "05:35", "Isha": "19:10"})
+	data.Mosque = "Masjid Noor"
+	data.Day.SetJumuah([]string{"13:00", "14:15"})
+	data.JumuahDuration = 45
+	data.Day.Traveler = true
+	data.Reminders = []string{"Read Surah al-Kahf"}
+	data.ShowDua = true
+	data.Dua = &prayer.Dua{ID: "test", Category: "daily", Arabic: "رَبِّ زِدْنِي عِلْمًا", Translation: "My Lord, increase me in knowledge.", Source: "Qur'an 20:114"}
+	data.ShowQibla = true
+	data.Qibla = &api.QiblaData{Direction: 136.1}
+	return data
+}
+
+// treeLeaves returns the values of a decoded JSON or YAML document by slash-separated
+// path, with list items under the path of the list
+func treeLeaves(tree any) map[string][]string {
+	leaves := make(map[string][]string)
+	var walk func(path string, node any)
+	walk = func(path string, node any) {
+		switch v := node.(type) {
+		case map[string]any:
+			for key, child := range v {
+				walk(strings.TrimPrefix(path+"/"+key, "/"), child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(path, child)
+			}
+		default:
+			leaves[path] = append(leaves[path], fmt.Sprint(v))
+		}
+	}
+	walk("", tree)
+	return leaves
+}
+
+// xmlLeaves returns the text of the elements of an XML document without child elements
+// by slash-separated path below the root element
+func xmlLeaves(doc []byte) (map[string][]string, error) {
+	leaves := make(map[string][]string)
+	decoder := xml.NewDecoder(bytes.NewReader(doc))
+	var path []string
+	var text string
+	hasChildren := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return leaves, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := token.(type) {
+		case xml.StartElement:
+			path = append(path, tok.Name.Local)
+			text, hasChildren = "", false
+		case xml.CharData:
+			text += string(tok)
+		case xml.EndElement:
+			if !hasChildren && len(path) > 1 {
+				key := strings.Join(path[1:], "/")
+				leaves[key] = append(leaves[key], text)
+			}
+			path = path[:len(path)-1]
+			hasChildren = true
+		}
+	}
+}
+
+func TestRakahLabel(t *testing.T) {
+	tests := map[string]string{
+		"Dhuhr":   "2 (qasr)",
+		"Maghrib": "3",
+		"Sunrise": "",
+	}
+	for name, want := range tests {
+		if got := rakahLabel(NewLocalizer("en", false), name); got != want {
+			t.Errorf("rakahLabel(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFormattersRenderDua(t *testing.T) {
+	data := createTestPrayerData()
+	data.ShowDua = true
+	data.Dua = &prayer.Dua{
+		ID:              "rabbi-zidni-ilma",
+		Category:        prayer.DuaDaily,
+		Arabic:          "رَبِّ زِدْنِي عِلْمًا",
+		Transliteration: "Rabbi zidni 'ilma",
+		Translation:     "My Lord, increase me in knowledge.",
+		Source:          "Qur'an 20:114",
+	}
+
+	formatters := map[string]Formatter{
+		"pretty":  &PrettyFormatter{},
+		"json":    &JSONFormatter{},
+		"slack":   &SlackFormatter{},
+		"discord": &DiscordFormatter{},
+		"webhook": &WebhookFormatter{},
+	}
+
+	for name, f := range formatters {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := f.Format(&buf, data); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), "Qur'an 20:114") {
+				t.Errorf("%s output missing du'a source", name)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lines := WrapText("one two three four five", 9)
+	want := []string{"one two", "three", "four five"}
+	if len(lines) != len(want) {
+		t.Fatalf("WrapText() = %v, want %v", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+
+	// Arabic diacritics do not take up columns
+	if got := DisplayWidth("رَبِّ"); got != 2 {
+		t.Errorf("DisplayWidth() = %d, want 2", got)
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+	en := NewLocalizer("en", false)
+	ar := NewLocalizer("ar", true)
+
+	if got := en.Prayer("Jumu'ah 2"); got != "Jumu'ah 2" {
+		t.Errorf("en Prayer() = %q", got)
+	}
+	if got := ar.Prayer("Maghrib + Isha"); got != "المغرب + العشاء" {
+		t.Errorf("ar Prayer() = %q", got)
+	}
+	if got := ar.Duration(90); got != "١ س ٣٠ د" {
+		t.Errorf("ar Duration() = %q", got)
+	}
+	if got := ar.Digits("\x1b[32m05:15\x1b[0m"); got != "\x1b[32m٠٥:١٥\x1b[0m" {
+		t.Errorf("ar Digits() = %q, want ANSI codes untouched", got)
+	}
+	if got := NewLocalizer("ar", false).T("label.rakah", 2); got != "الركعات: 2" {
+		t.Errorf("ar T() without native digits = %q", got)
+	}
+	date := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	if got := en.ShortDate(date); got != "Wed 4 Feb" {
+		t.Errorf("en ShortDate() = %q", got)
+	}
+	if got := NewLocalizer("tr", false).ShortDate(date); got != "Çarşamba 4 Şubat" {
+		t.Errorf("tr ShortDate() = %q", got)
+	}
+	if !ar.IsRTL() || en.IsRTL() {
+		t.Error("IsRTL() mismatch")
+	}
+
+	// Unknown languages and missing keys fall back to English
+	if got := NewLocalizer("xx", false).T("column.prayer"); got != "Prayer" {
+		t.Errorf("fallback T() = %q", got)
+	}
+	if got := ar.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("missing key T() = %q", got)
+	}
+}
+
+func TestFormattersArabic(t *testing.T) {
+	data := createTestPrayerData()
+	data.Language = "ar"
+	data.NativeDigits = true
+	data.Response.Data.Date.Gregorian = api.GregorianDate{
+		Day:   "04",
+		Month: api.MonthInfo{Number: 2, En: "February"},
+		Year:  "2026",
+	}
+	data.Response.Data.Date.Hijri.Weekday = api.Weekday{En: "Al Arba'a", Ar: "الاربعاء"}
+
+	formatters := map[string]Formatter{
+		"table":   &TableFormatter{},
+		"pretty":  &PrettyFormatter{},
+		"slack":   &SlackFormatter{},
+		"discord": &DiscordFormatter{},
+	}
+
+	for name, f := range formatters {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := f.Format(&buf, data); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			output := buf.String()
+			for _, want := range []string{"الفجر", "٠٥:١٥", "٠٤ فبراير ٢٠٢٦"} {
+				if !strings.Contains(output, want) {
+					t.Errorf("%s output missing %q", name, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrettyArabicAlignment(t *testing.T) {
+	data := createTestPrayerData()
+	data.Language = "ar"
+	data.NativeDigits = true
+
+	var buf bytes.Buffer
+	if err := (&PrettyFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	// Names are padded by display width, so the times line up after the widest name
+	for _, want := range []string{"منتصف الليل  ٠٠:٠٩", "الفجر" + strings.Repeat(" ", 8) + "٠٥:١٥"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("pretty output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestCatalogsComplete(t *testing.T) {
+	for _, c := range Catalogs() {
+		for key := range catalogEn.Messages {
+			if _, ok := c.Messages[key]; !ok {
+				t.Errorf("catalog %s missing %q", c.Code, key)
+			}
+		}
+	}
+
+	tr := NewLocalizer("tr", false)
+	for name, want := range map[string]string{"Fajr": "İmsak", "Dhuhr": "Öğle", "Asr": "İkindi", "Jumu'ah 2": "Cuma 2"} {
+		if got := tr.Prayer(name); got != want {
+			t.Errorf("tr Prayer(%s) = %q, want %q", name, got, want)
+		}
+	}
+
+	if got := NewLocalizer("ur", true).Digits("12:05"); got != "۱۲:۰۵" {
+		t.Errorf("ur Digits() = %q", got)
+	}
+	if GetCatalog(" FR ") == nil {
+		t.Error("GetCatalog() should ignore case and spaces")
+	}
+}
+
+func TestLocalizerTime(t *testing.T) {
+	at := time.Date(2026, 2, 4, 17, 34, 0, 0, time.UTC)
+	tests := []struct {
+		lang   string
+		digits bool
+		format string
+		want   string
+	}{
+		{"en", false, "", "17:34"},
+		{"en", false, "24h", "17:34"},
+		{"en", false, "12h", "5:34 PM"},
+		{"en", false, "locale", "5:34 PM"},
+		{"ar", true, "locale", "٥:٣٤ م"},
+		{"tr", false, "locale", "17:34"},
+		{"ms", false, "12h", "5:34 PTG"},
+	}
+	for _, tt := range tests {
+		l := NewLocalizer(tt.lang, tt.digits).WithTimeFormat(tt.format)
+		if got := l.Time(at); got != tt.want {
+			t.Errorf("Time(%s, %q) = %q, want %q", tt.lang, tt.format, got, tt.want)
+		}
+	}
+
+	l := NewLocalizer("en", false).WithTimeFormat("12h")
+	if got := l.Clock("00:09 (EET)"); got != "12:09 AM" {
+		t.Errorf("Clock() = %q, want 12:09 AM", got)
+	}
+	if got := l.Clock(""); got != "" {
+		t.Errorf("Clock(\"\") = %q, want empty", got)
+	}
+}
+
+func TestFormatters12Hour(t *testing.T) {
+	data := createTestPrayerData()
+	data.TimeFormat = "12h"
+
+	formatters := map[string]Formatter{
+		"table":   &TableFormatter{},
+		"pretty":  &PrettyFormatter{},
+		"slack":   &SlackFormatter{},
+		"discord": &DiscordFormatter{},
+	}
+
+	for name, f := range formatters {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := f.Format(&buf, data); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), "5:34 PM") {
+				t.Errorf("%s output missing 12-hour Maghrib time", name)
+			}
+		})
+	}
+}
+
+func TestGetTheme(t *testing.T) {
+	for _, name := range []string{"emoji", "unicode", "ascii", "plain"} {
+		th := GetTheme(name, false)
+		if th == nil || th.Name != name {
+			t.Errorf("GetTheme(%q) = %v", name, th)
+		}
+	}
+	if th := GetTheme("neon", false); th != nil {
+		t.Errorf("GetTheme(neon) = %v, want nil", th)
+	}
+	if th := GetTheme("auto", true); th == ThemeEmoji {
+		t.Error("GetTheme(auto) with no_emoji returned the emoji theme")
+	}
+
+	t.Setenv("LC_ALL", "C")
+	if th := DetectTheme(false); th != ThemeASCII {
+		t.Errorf("DetectTheme() with a non-UTF-8 locale = %s, want ascii", th.Name)
+	}
+}
+
+func TestBigText(t *testing.T) {
+	rows := ThemeASCII.BigText("1:0", 1)
+	want := []string{
+		"    ##      ######",
+		"    ##  ##  ##  ##",
+		"    ##      ##  ##",
+		"    ##  ##  ##  ##",
+		"    ##      ######",
+	}
+	if strings.Join(rows, "\n") != strings.Join(want, "\n") {
+		t.Errorf("BigText(1:0) =\n%s\nwant\n%s", strings.Join(rows, "\n"), strings.Join(want, "\n"))
+	}
+	if got := BigTextWidth("1:0", 1); got != len(want[0]) {
+		t.Errorf("BigTextWidth(1:0) = %d, want %d", got, len(want[0]))
+	}
+
+	if rows := ThemeASCII.BigText("12:34", 2); len(rows) != 2*BigTextHeight || len(rows[0]) != BigTextWidth("12:34", 2) {
+		t.Errorf("BigText() at scale 2 is %d rows of %d cells, want %d of %d",
+			len(rows), len(rows[0]), 2*BigTextHeight, BigTextWidth("12:34", 2))
+	}
+	if rows := ThemePlain.BigText("12:34", 1); rows != nil {
+		t.Errorf("BigText() with the plain theme = %v, want nil", rows)
+	}
+}
+
+func TestCompass(t *testing.T) {
+	rows := ThemeASCII.Compass(90, 4)
+	if len(rows) != 9 || len(rows[0]) != 17 {
+		t.Fatalf("Compass() is %d rows of %d cells, want 9 of 17", len(rows), len(rows[0]))
+	}
+	if rows[0][8] != 'N' || rows[8][8] != 'S' || rows[4][0] != 'W' || rows[4][16] != 'E' {
+		t.Errorf("Compass() labels misplaced:\n%s", strings.Join(rows, "\n"))
+	}
+	// Pointing east, the needle runs right from the center
+	if rows[4][8] != '+' || rows[4][10] != '#' || rows[4][6] == '#' || strings.Contains(rows[2], "#") {
+		t.Errorf("Compass(90) needle misplaced:\n%s", strings.Join(rows, "\n"))
+	}
+
+	if rows := ThemePlain.Compass(90, 4); rows != nil {
+		t.Errorf("Compass() with the plain theme = %v, want nil", rows)
+	}
+}
+
+func TestFormattersASCIITheme(t *testing.T) {
+	data := createTestPrayerData()
+	data.Theme = ThemeASCII
+
+	for name, f := range map[string]Formatter{"table": &TableFormatter{}, "pretty": &PrettyFormatter{}} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := f.Format(&buf, data); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			for _, r := range buf.String() {
+				if r > unicode.MaxASCII {
+					t.Fatalf("%s output contains non-ASCII character %q", name, r)
+				}
+			}
+			if !strings.Contains(buf.String(), "Fajr") {
+				t.Errorf("%s output missing Fajr", name)
+			}
+		})
+	}
+}
+
+func TestFormattersPlainTheme(t *testing.T) {
+	data := createTestPrayerData()
+	data.Theme = ThemePlain
+	data.Mosque = "Al-Azhar"
+
+	for name, f := range map[string]Formatter{"table": &TableFormatter{}, "pretty": &PrettyFormatter{}} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := f.Format(&buf, data); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			output := buf.String()
+			for _, want := range []string{"Fajr: 05:15", "Maghrib: 17:34", "Al-Azhar"} {
+				if !strings.Contains(output, want) {
+					t.Errorf("%s output missing %q", name, want)
+				}
+			}
+			if strings.ContainsAny(output, "│┌+|━") {
+				t.Errorf("%s output contains box drawing:\n%s", name, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"html/template"
+	"io"
+)
+
+// HTMLFormatter formats output as a self-contained, printable HTML page
+type HTMLFormatter struct{}
+
+// HTMLThemes lists the color themes of HTML output. "auto" follows the browser's
+// light or dark preference. Printed pages are always light.
+var HTMLThemes = []string{"light", "dark", "auto"}
+
+// htmlPage is the data of htmlTemplate
+type htmlPage struct {
+	*timetable
+	Theme string
+}
+
+var htmlTemplate = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}" dir="{{if .RTL}}rtl{{else}}ltr{{end}}" class="{{.Theme}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+:root { --bg: #ffffff; --fg: #1f2328; --muted: #59636e; --line: #d1d9e0; --head: #f6f8fa; --accent: #1a7f37; }
+html.dark { --bg: #0d1117; --fg: #e6edf3; --muted: #9198a1; --line: #3d444d; --head: #151b23; --accent: #3fb950; }
+@media (prefers-color-scheme: dark) {
+  html.auto { --bg: #0d1117; --fg: #e6edf3; --muted: #9198a1; --line: #3d444d; --head: #151b23; --accent: #3fb950; }
+}
+html { background: var(--bg); color: var(--fg); }
+body { font-family: system-ui, -apple-system, "Segoe UI", "Noto Sans", "Noto Naskh Arabic", sans-serif; margin: 2rem auto; max-width: 60rem; padding: 0 1rem; }
+h1 { font-size: 1.6rem; margin: 0 0 .25rem; }
+.subtitle { color: var(--muted); margin: 0 0 1.25rem; }
+table { border-collapse: collapse; width: 100%; font-variant-numeric: tabular-nums; }
+th, td { border: 1px solid var(--line); padding: .45rem .7rem; text-align: start; }
+th { background: var(--head); }
+tbody tr:nth-child(even) { background: var(--head); }
+small { display: block; color: var(--accent); }
+footer { color: var(--muted); font-size: .85rem; margin-top: 1.25rem; }
+footer p { margin: .15rem 0; }
+@media print {
+  html, html.dark, html.auto { --bg: #ffffff; --fg: #000000; --muted: #444444; --line: #999999; --head: #eeeeee; --accent: #000000; }
+  body { margin: 0; max-width: none; }
+  table { page-break-inside: auto; }
+  tr { page-break-inside: avoid; }
+}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="subtitle">{{.Subtitle}}</p>
+<table>
+<thead>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.Text}}{{if .Sub}}<small>{{.Sub}}</small>{{end}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+<footer>
+{{- range .Footer}}
+<p>{{.}}</p>
+{{- end}}
+</footer>
+</body>
+</html>
+`))
+
+// Format writes a day's prayer times as an HTML page
+func (f *HTMLFormatter) Format(w io.Writer, data *PrayerData) error {
+	return f.FormatRange(w, []*PrayerData{data})
+}
+
+// FormatRange writes the prayer times of several days as an HTML timetable
+func (f *HTMLFormatter) FormatRange(w io.Writer, days []*PrayerData) error {
+	t, err := buildTimetable(days)
+	if err != nil {
+		return err
+	}
+
+	theme := days[0].HTMLTheme
+	if theme == "" {
+		theme = "light"
+	}
+	return htmlTemplate.Execute(w, htmlPage{timetable: t, Theme: theme})
+}
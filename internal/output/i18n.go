@@ -0,0 +1,267 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/api"
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// Catalog holds the translated labels for one language
+type Catalog struct {
+	Code     string            // Language code (e.g., "ar")
+	Name     string            // English name of the language
+	Native   string            // Name of the language in itself
+	RTL      bool              // Written right-to-left
+	Digits   string            // Native digits 0-9, empty when the language uses Latin digits
+	Clock    string            // Clock used by the "locale" time format: "12h" or "24h"
+	Messages map[string]string // Labels keyed by message ID
+}
+
+// catalogs lists the available languages, English first
+var catalogs = []*Catalog{catalogEn, catalogAr, catalogUr, catalogTr, catalogID, catalogFr, catalogMs}
+
+// Catalogs returns the available language catalogs
+func Catalogs() []*Catalog {
+	result := make([]*Catalog, len(catalogs))
+	copy(result, catalogs)
+	return result
+}
+
+// Languages returns the codes of the available languages
+func Languages() []string {
+	codes := make([]string, 0, len(catalogs))
+	for _, c := range catalogs {
+		codes = append(codes, c.Code)
+	}
+	return codes
+}
+
+// GetCatalog returns the catalog for a language code, or nil if it is not available
+func GetCatalog(code string) *Catalog {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, c := range catalogs {
+		if c.Code == code {
+			return c
+		}
+	}
+	return nil
+}
+
+// Localizer renders labels, prayer names, dates and durations in one language
+type Localizer struct {
+	catalog      *Catalog
+	nativeDigits bool
+	timeFormat   string
+}
+
+// NewLocalizer creates a Localizer for a language, falling back to English.
+// When nativeDigits is set, numbers are rendered with the language's native digits.
+func NewLocalizer(lang string, nativeDigits bool) *Localizer {
+	catalog := GetCatalog(lang)
+	if catalog == nil {
+		catalog = catalogEn
+	}
+	return &Localizer{catalog: catalog, nativeDigits: nativeDigits, timeFormat: "24h"}
+}
+
+// WithTimeFormat sets the clock used for times: "24h", "12h" or "locale"
+func (l *Localizer) WithTimeFormat(format string) *Localizer {
+	if format != "" {
+		l.timeFormat = format
+	}
+	return l
+}
+
+// Lang returns the language code in use
+func (l *Localizer) Lang() string {
+	return l.catalog.Code
+}
+
+// IsRTL reports whether the language is written right-to-left
+func (l *Localizer) IsRTL() bool {
+	return l.catalog.RTL
+}
+
+// T returns the label for a message ID formatted with args, falling back to English
+func (l *Localizer) T(key string, args ...interface{}) string {
+	msg, ok := l.catalog.Messages[key]
+	if !ok {
+		if msg, ok = catalogEn.Messages[key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return l.Digits(msg)
+}
+
+// Digits converts ASCII digits to the language's native digits when enabled
+func (l *Localizer) Digits(s string) string {
+	if !l.nativeDigits || l.catalog.Digits == "" {
+		return s
+	}
+	digits := []rune(l.catalog.Digits)
+	var b strings.Builder
+	escape := false
+	for _, r := range s {
+		switch {
+		case r == '\x1b':
+			// Leave ANSI color sequences intact
+			escape = true
+		case escape:
+			if r >= '@' && r <= '~' && r != '[' {
+				escape = false
+			}
+		case r >= '0' && r <= '9':
+			r = digits[r-'0']
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Is12Hour reports whether times are formatted with the 12-hour clock
+func (l *Localizer) Is12Hour() bool {
+	format := l.timeFormat
+	if format == "locale" {
+		format = l.catalog.Clock
+	}
+	return format == "12h"
+}
+
+// Time formats a time of day with the configured clock (e.g., "17:34" or "5:34 PM")
+func (l *Localizer) Time(t time.Time) string {
+	if !l.Is12Hour() {
+		return l.Digits(t.Format("15:04"))
+	}
+	marker := l.T("time.am")
+	if t.Hour() >= 12 {
+		marker = l.T("time.pm")
+	}
+	return l.Digits(t.Format("3:04")) + " " + marker
+}
+
+// Clock formats an API time string (e.g., "17:34" or "17:34 (EET)") with the configured clock,
+// returning it unchanged apart from digits when it cannot be parsed
+func (l *Localizer) Clock(s string) string {
+	t, err := time.Parse("15:04", cleanTime(s))
+	if err != nil {
+		return l.Digits(s)
+	}
+	return l.Time(t)
+}
+
+// Prayer returns the localized name of a prayer, including Jumu'ah slots
+// (e.g., "Jumu'ah 2") and combined traveler windows (e.g., "Dhuhr + Asr")
+func (l *Localizer) Prayer(name string) string {
+	if first, second, ok := strings.Cut(name, " + "); ok {
+		return l.Prayer(first) + " + " + l.Prayer(second)
+	}
+	if rest, ok := strings.CutPrefix(name, prayer.JumuahName); ok {
+		return l.T("prayer."+prayer.JumuahName) + l.Digits(rest)
+	}
+	return l.T("prayer." + name)
+}
+
+// Duration returns a short relative duration (e.g., "1h 30m")
+func (l *Localizer) Duration(mins int) string {
+	if mins < 60 {
+		return l.T("duration.minutes", mins)
+	}
+	hours := mins / 60
+	remaining := mins % 60
+	if remaining == 0 {
+		return l.T("duration.hours", hours)
+	}
+	return l.T("duration.hoursMinutes", hours, remaining)
+}
+
+// Date returns the localized Gregorian date with its weekday, or the API's readable
+// date when the language has no month names
+func (l *Localizer) Date(date api.Date) string {
+	g := date.Gregorian
+	month, ok := l.catalog.Messages[fmt.Sprintf("month.%d", g.Month.Number)]
+	if !ok || g.Day == "" {
+		return l.Digits(date.Readable)
+	}
+	result := l.Digits(fmt.Sprintf("%s %s %s", g.Day, month, g.Year))
+	if weekday := l.weekday(date); weekday != "" {
+		result = weekday + l.T("separator.list") + result
+	}
+	return result
+}
+
+// DateOf returns the localized Gregorian date of t with its weekday, or an English date
+// when the language has no month names
+func (l *Localizer) DateOf(t time.Time) string {
+	month, ok := l.catalog.Messages[fmt.Sprintf("month.%d", int(t.Month()))]
+	if !ok {
+		return l.Digits(t.Format("Monday, 2 January 2006"))
+	}
+	result := l.Digits(fmt.Sprintf("%d %s %d", t.Day(), month, t.Year()))
+	if weekday, ok := l.catalog.Messages["weekday."+t.Weekday().String()]; ok {
+		result = weekday + l.T("separator.list") + result
+	}
+	return result
+}
+
+// ShortDate returns the localized weekday and day of t (e.g., "Mon 2 Jan"), or an English
+// date when the language has no month names
+func (l *Localizer) ShortDate(t time.Time) string {
+	month, ok := l.catalog.Messages[fmt.Sprintf("month.%d", int(t.Month()))]
+	if !ok {
+		return l.Digits(t.Format("Mon 2 Jan"))
+	}
+	result := l.Digits(fmt.Sprintf("%d %s", t.Day(), month))
+	if weekday, ok := l.catalog.Messages["weekday."+t.Weekday().String()]; ok {
+		result = weekday + " " + result
+	}
+	return result
+}
+
+// weekday returns the localized weekday name, using the Arabic name from the API when available
+func (l *Localizer) weekday(date api.Date) string {
+	if name, ok := l.catalog.Messages["weekday."+date.Gregorian.Weekday.En]; ok {
+		return name
+	}
+	if l.catalog.Code == "ar" {
+		return date.Hijri.Weekday.Ar
+	}
+	return ""
+}
+
+// Hijri returns the localized Hijri date, using the Arabic month name from the API when available
+func (l *Localizer) Hijri(hijri api.HijriDate) string {
+	return l.HijriDate(prayer.HijriDate{
+		Day:     hijri.Day,
+		Month:   hijri.Month.Number,
+		MonthEn: hijri.Month.En,
+		MonthAr: hijri.Month.Ar,
+		Year:    hijri.Year,
+	})
+}
+
+// HijriDate returns the localized Hijri date of a prayer day, or "" when it is unknown
+// (e.g., for estimated days)
+func (l *Localizer) HijriDate(hijri prayer.HijriDate) string {
+	if hijri.Day == "" {
+		return ""
+	}
+	month := hijri.MonthEn
+	if name, ok := l.catalog.Messages[fmt.Sprintf("hijri.%d", hijri.Month)]; ok {
+		month = name
+	} else if l.catalog.Code == "ar" && hijri.MonthAr != "" {
+		month = hijri.MonthAr
+	}
+	return l.Digits(fmt.Sprintf("%s %s %s", hijri.Day, month, hijri.Year))
+}
+
+// DuaTitle returns the localized heading for a du'a category
+func (l *Localizer) DuaTitle(d *prayer.Dua) string {
+	return l.T("dua." + d.Category)
+}
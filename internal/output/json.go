@@ -3,86 +3,163 @@ package output
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
-	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 // JSONFormatter formats output as JSON
 type JSONFormatter struct{}
 
-// JSONOutput represents the JSON output structure
+// JSONOutput represents the JSON output structure, the data of its Envelope. The YAML
+// and XML formats marshal the same structure with the same field names.
 type JSONOutput struct {
-	Date       DateOutput        `json:"date"`
-	Location   LocationOutput    `json:"location"`
-	Method     MethodOutput      `json:"method"`
-	Timings    TimingsOutput     `json:"timings"`
-	NextPrayer *NextPrayerOutput `json:"nextPrayer,omitempty"`
-	Qibla      *QiblaOutput      `json:"qibla,omitempty"`
+	Date       DateOutput        `json:"date" yaml:"date" xml:"date"`
+	Location   LocationOutput    `json:"location" yaml:"location" xml:"location"`
+	Method     MethodOutput      `json:"method" yaml:"method" xml:"method"`
+	Timings    TimingsOutput     `json:"timings" yaml:"timings" xml:"timings"`
+	Iqama      *IqamaOutput      `json:"iqama,omitempty" yaml:"iqama,omitempty" xml:"iqama,omitempty"`
+	NextPrayer *NextPrayerOutput `json:"nextPrayer,omitempty" yaml:"nextPrayer,omitempty" xml:"nextPrayer,omitempty"`
+	Qibla      *QiblaOutput      `json:"qibla,omitempty" yaml:"qibla,omitempty" xml:"qibla,omitempty"`
+	Mosque     *MosqueOutput     `json:"mosque,omitempty" yaml:"mosque,omitempty" xml:"mosque,omitempty"`
+	Jumuah     *JumuahOutput     `json:"jumuah,omitempty" yaml:"jumuah,omitempty" xml:"jumuah,omitempty"`
+	Traveler   *TravelerOutput   `json:"traveler,omitempty" yaml:"traveler,omitempty" xml:"traveler,omitempty"`
+	Reminders  []string          `json:"reminders,omitempty" yaml:"reminders,omitempty" xml:"reminders,omitempty"`
+	Dua        *prayer.Dua       `json:"dua,omitempty" yaml:"dua,omitempty" xml:"dua,omitempty"`
 }
 
 // DateOutput represents date information in JSON
 type DateOutput struct {
-	Gregorian string       `json:"gregorian"`
-	Hijri     *HijriOutput `json:"hijri,omitempty"`
+	Gregorian string       `json:"gregorian" yaml:"gregorian" xml:"gregorian"`
+	Hijri     *HijriOutput `json:"hijri,omitempty" yaml:"hijri,omitempty" xml:"hijri,omitempty"`
 }
 
 // HijriOutput represents Hijri date in JSON
 type HijriOutput struct {
-	Day   string      `json:"day"`
-	Month MonthOutput `json:"month"`
-	Year  string      `json:"year"`
+	Day   string      `json:"day" yaml:"day" xml:"day"`
+	Month MonthOutput `json:"month" yaml:"month" xml:"month"`
+	Year  string      `json:"year" yaml:"year" xml:"year"`
 }
 
 // MonthOutput represents month information
 type MonthOutput struct {
-	Number int    `json:"number"`
-	En     string `json:"en"`
-	Ar     string `json:"ar,omitempty"`
+	Number int    `json:"number" yaml:"number" xml:"number"`
+	En     string `json:"en" yaml:"en" xml:"en"`
+	Ar     string `json:"ar,omitempty" yaml:"ar,omitempty" xml:"ar,omitempty"`
 }
 
 // LocationOutput represents location in JSON
 type LocationOutput struct {
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Timezone  string  `json:"timezone"`
-	Address   string  `json:"address,omitempty"`
+	Latitude  float64 `json:"latitude" yaml:"latitude" xml:"latitude"`
+	Longitude float64 `json:"longitude" yaml:"longitude" xml:"longitude"`
+	Timezone  string  `json:"timezone" yaml:"timezone" xml:"timezone"`
+	Address   string  `json:"address,omitempty" yaml:"address,omitempty" xml:"address,omitempty"`
 }
 
 // MethodOutput represents calculation method in JSON
 type MethodOutput struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID   int    `json:"id" yaml:"id" xml:"id"`
+	Name string `json:"name" yaml:"name" xml:"name"`
 }
 
 // TimingsOutput represents prayer times
 type TimingsOutput struct {
-	Fajr     string `json:"Fajr"`
-	Sunrise  string `json:"Sunrise"`
-	Dhuhr    string `json:"Dhuhr"`
-	Asr      string `json:"Asr"`
-	Maghrib  string `json:"Maghrib"`
-	Isha     string `json:"Isha"`
-	Midnight string `json:"Midnight"`
+	Fajr     string `json:"Fajr" yaml:"Fajr" xml:"Fajr"`
+	Sunrise  string `json:"Sunrise" yaml:"Sunrise" xml:"Sunrise"`
+	Dhuhr    string `json:"Dhuhr" yaml:"Dhuhr" xml:"Dhuhr"`
+	Asr      string `json:"Asr" yaml:"Asr" xml:"Asr"`
+	Maghrib  string `json:"Maghrib" yaml:"Maghrib" xml:"Maghrib"`
+	Isha     string `json:"Isha" yaml:"Isha" xml:"Isha"`
+	Midnight string `json:"Midnight" yaml:"Midnight" xml:"Midnight"`
+}
+
+// IqamaOutput represents iqama (congregation) times
+type IqamaOutput struct {
+	Fajr    string `json:"Fajr,omitempty" yaml:"Fajr,omitempty" xml:"Fajr,omitempty"`
+	Dhuhr   string `json:"Dhuhr,omitempty" yaml:"Dhuhr,omitempty" xml:"Dhuhr,omitempty"`
+	Asr     string `json:"Asr,omitempty" yaml:"Asr,omitempty" xml:"Asr,omitempty"`
+	Maghrib string `json:"Maghrib,omitempty" yaml:"Maghrib,omitempty" xml:"Maghrib,omitempty"`
+	Isha    string `json:"Isha,omitempty" yaml:"Isha,omitempty" xml:"Isha,omitempty"`
+}
+
+// MosqueOutput represents the active mosque profile
+type MosqueOutput struct {
+	Name string `json:"name" yaml:"name" xml:"name"`
+}
+
+// JumuahOutput represents the Friday prayer, which replaces Dhuhr
+type JumuahOutput struct {
+	Times    []string `json:"times" yaml:"times" xml:"times"`
+	Duration int      `json:"duration,omitempty" yaml:"duration,omitempty" xml:"duration,omitempty"`
+}
+
+// TravelerOutput represents shortened (qasr) rak'ahs and combined (jam') prayer windows
+type TravelerOutput struct {
+	Rakahs   RakahsOutput     `json:"rakahs" yaml:"rakahs" xml:"rakahs"`
+	Combined []CombinedOutput `json:"combined" yaml:"combined" xml:"combined"`
+}
+
+// RakahsOutput maps prayer names to their traveler rak'ah counts
+type RakahsOutput map[string]int
+
+// MarshalXML writes the rak'ah counts as elements named after the prayers, in prayer order
+func (r RakahsOutput) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, name := range prayer.IqamaPrayers {
+		if count, ok := r[name]; ok {
+			if err := e.EncodeElement(count, xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+				return err
+			}
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// CombinedOutput represents a window in which two prayers may be combined
+type CombinedOutput struct {
+	Name    string   `json:"name" yaml:"name" xml:"name"`
+	Prayers []string `json:"prayers" yaml:"prayers" xml:"prayers"`
+	Taqdim  string   `json:"taqdim" yaml:"taqdim" xml:"taqdim"`
+	Takhir  string   `json:"takhir" yaml:"takhir" xml:"takhir"`
+	End     string   `json:"end" yaml:"end" xml:"end"`
 }
 
 // NextPrayerOutput represents the next prayer
 type NextPrayerOutput struct {
-	Name         string `json:"name"`
-	Time         string `json:"time"`
-	MinutesUntil int    `json:"minutesUntil"`
+	Name         string `json:"name" yaml:"name" xml:"name"`
+	Time         string `json:"time" yaml:"time" xml:"time"`
+	Date         string `json:"date" yaml:"date" xml:"date"`
+	Tomorrow     bool   `json:"tomorrow,omitempty" yaml:"tomorrow,omitempty" xml:"tomorrow,omitempty"`
+	Iqama        string `json:"iqama,omitempty" yaml:"iqama,omitempty" xml:"iqama,omitempty"`
+	MinutesUntil int    `json:"minutesUntil" yaml:"minutesUntil" xml:"minutesUntil"`
 }
 
 // QiblaOutput represents Qibla direction
 type QiblaOutput struct {
-	Direction float64 `json:"direction"`
-	Compass   string  `json:"compass"`
+	Direction float64 `json:"direction" yaml:"direction" xml:"direction"`
+	Compass   string  `json:"compass" yaml:"compass" xml:"compass"`
 }
 
-// Format writes the prayer times as JSON
+// Format writes the prayer times as JSON, in an Envelope
 func (f *JSONFormatter) Format(w io.Writer, data *PrayerData) error {
-	if data.Response == nil {
-		return fmt.Errorf("no prayer times data")
+	output, err := buildJSONOutput(data)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(NewEnvelope(output))
+}
+
+// buildJSONOutput converts the prayer data to the structure shared by the JSON, YAML and XML formats
+func buildJSONOutput(data *PrayerData) (*JSONOutput, error) {
+	if data.Response == nil || data.Day == nil {
+		return nil, fmt.Errorf("no prayer times data")
 	}
 
 	resp := data.Response
@@ -90,7 +167,7 @@ func (f *JSONFormatter) Format(w io.Writer, data *PrayerData) error {
 	date := resp.Data.Date
 	meta := resp.Data.Meta
 
-	output := JSONOutput{
+	output := &JSONOutput{
 		Date: DateOutput{
 			Gregorian: date.Readable,
 		},
@@ -129,42 +206,25 @@ func (f *JSONFormatter) Format(w io.Writer, data *PrayerData) error {
 		}
 	}
 
-	// Calculate next prayer
-	now := time.Now()
-	tz := meta.Timezone
-	if tz != "" {
-		loc, err := time.LoadLocation(tz)
-		if err == nil {
-			now = time.Now().In(loc)
-		}
+	output.Iqama = buildIqamaOutput(data)
+	output.Mosque = buildMosqueOutput(data)
+	output.Jumuah = buildJumuahOutput(data)
+	output.Traveler = buildTravelerOutput(data)
+	if data.HasDua() {
+		output.Dua = data.Dua
 	}
+	output.Reminders = data.Reminders
 
-	prayers := []struct {
-		name string
-		time string
-	}{
-		{"Fajr", cleanTime(timings.Fajr)},
-		{"Sunrise", cleanTime(timings.Sunrise)},
-		{"Dhuhr", cleanTime(timings.Dhuhr)},
-		{"Asr", cleanTime(timings.Asr)},
-		{"Maghrib", cleanTime(timings.Maghrib)},
-		{"Isha", cleanTime(timings.Isha)},
-		{"Midnight", cleanTime(timings.Midnight)},
-	}
-
-	for _, p := range prayers {
-		prayerTime, err := parseTimeToday(p.time, now)
-		if err != nil {
-			continue
-		}
-		if now.Before(prayerTime) {
-			mins := int(time.Until(prayerTime).Minutes())
-			output.NextPrayer = &NextPrayerOutput{
-				Name:         p.name,
-				Time:         p.time,
-				MinutesUntil: mins,
-			}
-			break
+	// Next prayer
+	now := data.now()
+	if next := data.Day.NextPrayer(now); next != nil {
+		output.NextPrayer = &NextPrayerOutput{
+			Name:         next.Name,
+			Time:         next.Clock(),
+			Date:         next.Time.Format("2006-01-02"),
+			Tomorrow:     data.Day.IsLaterDate(next.Time),
+			Iqama:        iqamaClock(next),
+			MinutesUntil: int(next.Time.Sub(now).Minutes()),
 		}
 	}
 
@@ -172,11 +232,86 @@ func (f *JSONFormatter) Format(w io.Writer, data *PrayerData) error {
 	if data.ShowQibla && data.Qibla != nil {
 		output.Qibla = &QiblaOutput{
 			Direction: data.Qibla.Direction,
-			Compass:   getCompassDirection(data.Qibla.Direction),
+			Compass:   CompassDirection(data.Qibla.Direction),
 		}
 	}
+	return output, nil
+}
 
-	encoder := json.NewEncoder(w)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+// iqamaClock returns the event's iqama time (HH:MM), or "" when it has none
+func iqamaClock(e *prayer.Event) string {
+	if !e.HasIqama() {
+		return ""
+	}
+	return e.Iqama.Format("15:04")
+}
+
+// buildIqamaOutput converts iqama times to the JSON structure, or nil when disabled
+func buildIqamaOutput(data *PrayerData) *IqamaOutput {
+	if !data.HasIqama() {
+		return nil
+	}
+	iqama := func(name string) string {
+		if e := data.Day.Event(name); e != nil {
+			return iqamaClock(e)
+		}
+		return ""
+	}
+	return &IqamaOutput{
+		Fajr:    iqama("Fajr"),
+		Dhuhr:   iqama("Dhuhr"),
+		Asr:     iqama("Asr"),
+		Maghrib: iqama("Maghrib"),
+		Isha:    iqama("Isha"),
+	}
+}
+
+// buildMosqueOutput converts the mosque profile details to the JSON structure, or nil when unset
+func buildMosqueOutput(data *PrayerData) *MosqueOutput {
+	if data.Mosque == "" {
+		return nil
+	}
+	return &MosqueOutput{
+		Name: data.Mosque,
+	}
+}
+
+// buildJumuahOutput converts the Jumu'ah times to the JSON structure, or nil when not a Friday
+func buildJumuahOutput(data *PrayerData) *JumuahOutput {
+	if !data.HasJumuah() {
+		return nil
+	}
+	var times []string
+	for _, e := range data.Day.Jumuah() {
+		times = append(times, e.Clock())
+	}
+	return &JumuahOutput{
+		Times:    times,
+		Duration: data.JumuahDuration,
+	}
+}
+
+// buildTravelerOutput converts traveler details to the JSON structure, or nil when disabled
+func buildTravelerOutput(data *PrayerData) *TravelerOutput {
+	if !data.Day.Traveler {
+		return nil
+	}
+
+	output := &TravelerOutput{
+		Rakahs:   make(RakahsOutput),
+		Combined: []CombinedOutput{},
+	}
+	for _, name := range prayer.IqamaPrayers {
+		output.Rakahs[name] = prayer.RakahCount(name, true)
+	}
+	for _, cw := range data.Day.Windows {
+		output.Combined = append(output.Combined, CombinedOutput{
+			Name:    cw.Name,
+			Prayers: []string{cw.First, cw.Second},
+			Taqdim:  cw.Taqdim,
+			Takhir:  cw.Takhir,
+			End:     cw.End,
+		})
+	}
+	return output
 }
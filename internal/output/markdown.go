@@ -0,0 +1,72 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarkdownFormatter formats output as a GitHub-flavored Markdown table
+type MarkdownFormatter struct{}
+
+// Format writes a day's prayer times as Markdown
+func (f *MarkdownFormatter) Format(w io.Writer, data *PrayerData) error {
+	return f.FormatRange(w, []*PrayerData{data})
+}
+
+// FormatRange writes the prayer times of several days as a Markdown timetable
+func (f *MarkdownFormatter) FormatRange(w io.Writer, days []*PrayerData) error {
+	t, err := buildTimetable(days)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", markdownEscape(t.Title))
+	fmt.Fprintf(&b, "**%s**\n\n", markdownEscape(t.Subtitle))
+
+	fmt.Fprintf(&b, "| %s |\n", strings.Join(markdownCells(t.Header), " | "))
+	b.WriteString("|" + strings.Repeat(" --- |", len(t.Header)) + "\n")
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = cell.Text
+			if cell.Sub != "" {
+				cells[i] += " (" + cell.Sub + ")"
+			}
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(markdownCells(cells), " | "))
+	}
+
+	b.WriteString("\n")
+	for i, line := range t.Footer {
+		if i == len(t.Footer)-1 {
+			fmt.Fprintf(&b, "_%s_\n", markdownEscape(line))
+		} else {
+			fmt.Fprintf(&b, "%s  \n", markdownEscape(line))
+		}
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCells escapes the cells of a table row
+func markdownCells(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(markdownEscape(cell), "|", `\|`)
+	}
+	return escaped
+}
+
+// markdownEscape escapes the characters that would start emphasis or links in text
+var markdownEscape = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+).Replace
@@ -0,0 +1,54 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// NDJSONFormatter formats output as newline-delimited JSON: one self-contained Envelope
+// per line, with the data of the JSON format
+type NDJSONFormatter struct{}
+
+// Format writes the day's prayer times as a line of JSON
+func (f *NDJSONFormatter) Format(w io.Writer, data *PrayerData) error {
+	return f.FormatRange(w, []*PrayerData{data})
+}
+
+// FormatRange writes the prayer times of several days as JSON, one line per day
+func (f *NDJSONFormatter) FormatRange(w io.Writer, days []*PrayerData) error {
+	if len(days) == 0 {
+		return errors.New("no prayer times data")
+	}
+	for _, data := range days {
+		if data == nil {
+			return errors.New("no prayer times data")
+		}
+		output, err := buildJSONOutput(data)
+		if err != nil {
+			return err
+		}
+		if err := WriteNDJSON(w, output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteNDJSON writes data in an Envelope as a line of JSON. The line is written at once
+// and w is flushed when it is buffered, so a reader at the other end of a pipe gets each
+// line as soon as it is written.
+func WriteNDJSON(w io.Writer, data any) error {
+	line, err := json.Marshal(NewEnvelope(data))
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if f, ok := w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
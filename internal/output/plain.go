@@ -0,0 +1,78 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// formatPlain writes the prayer times as plain lines for screen readers:
+// one fact per line, no colors, icons, box drawing or column alignment
+func formatPlain(w io.Writer, data *PrayerData) error {
+	date := data.Response.Data.Date
+	day := data.Day
+	l := data.localizer()
+	now := data.now()
+	next := day.Next(now)
+
+	fmt.Fprintln(w, l.T("heading.title", data.Location))
+	fmt.Fprintln(w, l.Date(date))
+	if data.ShowHijri && data.HijriFormat != "none" {
+		fmt.Fprintln(w, l.Hijri(date.Hijri))
+	}
+	fmt.Fprintln(w)
+
+	for _, p := range day.Events {
+		parts := []string{l.Prayer(p.Name) + ": " + l.Time(p.Time)}
+		if p.HasIqama() {
+			parts = append(parts, l.T("label.iqama", l.Time(p.Iqama)))
+		}
+		if data.Day.Traveler {
+			if count := prayer.RakahCount(p.Name, true); count > 0 {
+				rakah := l.T("label.rakah", count)
+				if prayer.IsShortened(p.Name) {
+					rakah += " " + l.T("label.qasr")
+				}
+				parts = append(parts, rakah)
+			}
+		}
+		if now.After(p.Time) {
+			parts = append(parts, l.T("status.passed"))
+		} else if next != nil && p.Name == next.Name {
+			parts = append(parts, l.T("status.nextPrayer", l.Duration(int(p.Time.Sub(now).Minutes()))))
+		}
+		fmt.Fprintln(w, strings.Join(parts, ", "))
+	}
+	if line := data.nextTomorrow(l, now); line != "" {
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w)
+
+	if data.ShowQibla && data.Qibla != nil {
+		fmt.Fprintln(w, l.T("label.qibla", data.Qibla.Direction, CompassDirection(data.Qibla.Direction)))
+	}
+	if data.Day.Traveler {
+		fmt.Fprintln(w, l.T("traveler.title"))
+		for _, cw := range day.Windows {
+			fmt.Fprintln(w, l.T("traveler.window", l.Prayer(cw.Name), l.Clock(cw.Taqdim), l.Clock(cw.Takhir), l.Clock(cw.End)))
+		}
+	}
+	if data.Mosque != "" {
+		fmt.Fprintln(w, l.T("label.mosque", data.Mosque))
+	}
+	for _, r := range data.Reminders {
+		fmt.Fprintln(w, r)
+	}
+	if data.HasDua() {
+		fmt.Fprintln(w, l.DuaTitle(data.Dua)+":")
+		fmt.Fprintln(w, data.Dua.Arabic)
+		fmt.Fprintln(w, data.Dua.Translation)
+		fmt.Fprintln(w, duaSource(data.Dua))
+	}
+	fmt.Fprintln(w, l.T("label.method", data.Method))
+
+	return nil
+}
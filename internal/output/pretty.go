@@ -2,25 +2,27 @@
 package output
 
 import (
+	"errors"
 	"fmt"
 	"io"
-	"time"
+	"strings"
 
 	"github.com/fatih/color"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 // PrettyFormatter formats output with colors and emojis
 type PrettyFormatter struct{}
 
-// Format writes the prayer times in a pretty format with colors and emojis
+// Format writes the prayer times in a pretty format with colors and theme icons
 func (f *PrettyFormatter) Format(w io.Writer, data *PrayerData) error {
-	if data.Response == nil {
-		return fmt.Errorf("no prayer times data")
+	if data.Response == nil || data.Day == nil {
+		return errors.New(data.localizer().T("error.noData"))
 	}
 
-	resp := data.Response
-	timings := resp.Data.Timings
-	date := resp.Data.Date
+	date := data.Response.Data.Date
+	day := data.Day
 
 	// Colors
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -33,71 +35,73 @@ func (f *PrettyFormatter) Format(w io.Writer, data *PrayerData) error {
 		color.NoColor = true
 	}
 
+	th := data.theme()
+	if th.Plain {
+		return formatPlain(w, data)
+	}
+
+	l := data.localizer()
+
 	// Header
 	fmt.Fprintln(w)
-	fmt.Fprintf(w, "🕌 %s\n", bold(fmt.Sprintf("Prayer Times for %s", data.Location)))
-	fmt.Fprintf(w, "📅 %s", date.Readable)
+	fmt.Fprintf(w, "%s%s\n", th.Prefix("mosque"), bold(l.T("heading.titleFor", data.Location)))
+	fmt.Fprintf(w, "%s%s", th.Prefix("calendar"), l.Date(date))
 
 	if data.ShowHijri && data.HijriFormat != "none" {
-		hijri := date.Hijri
-		fmt.Fprintf(w, " | %s %s %s", hijri.Day, hijri.Month.En, hijri.Year)
+		fmt.Fprintf(w, " | %s", l.Hijri(date.Hijri))
 	}
 	fmt.Fprintln(w)
 	fmt.Fprintln(w)
 
-	// Prayers
-	prayers := []struct {
-		name  string
-		time  string
-		emoji string
-	}{
-		{"Fajr", cleanTime(timings.Fajr), "🌅"},
-		{"Sunrise", cleanTime(timings.Sunrise), "🌄"},
-		{"Dhuhr", cleanTime(timings.Dhuhr), "☀️"},
-		{"Asr", cleanTime(timings.Asr), "🌤️"},
-		{"Maghrib", cleanTime(timings.Maghrib), "🌆"},
-		{"Isha", cleanTime(timings.Isha), "🌙"},
-		{"Midnight", cleanTime(timings.Midnight), "🌃"},
-	}
+	// Current time and next prayer
+	now := data.now()
+	next := day.Next(now)
+	prayers := day.Events
 
-	// Get current time
-	now := time.Now()
-	tz := resp.Data.Meta.Timezone
-	if tz != "" {
-		loc, err := time.LoadLocation(tz)
-		if err == nil {
-			now = time.Now().In(loc)
-		}
-	}
-
-	// Find next prayer
-	var nextPrayerIdx int = -1
+	// Column widths depend on the language (e.g., "منتصف الليل") and the clock (e.g., "05:15" or "5:15 AM")
+	names := make([]string, len(prayers))
+	times := make([]string, len(prayers))
+	iqamas := make([]string, len(prayers))
+	nameWidth, timeWidth, iqamaWidth := 8, 0, 0
 	for i, p := range prayers {
-		prayerTime, err := parseTimeToday(p.time, now)
-		if err != nil {
-			continue
-		}
-		if now.Before(prayerTime) {
-			nextPrayerIdx = i
-			break
+		names[i] = l.Prayer(p.Name)
+		nameWidth = max(nameWidth, DisplayWidth(names[i]))
+		times[i] = l.Time(p.Time)
+		timeWidth = max(timeWidth, DisplayWidth(times[i]))
+		if p.HasIqama() {
+			iqamas[i] = l.T("label.iqama", l.Time(p.Iqama))
+			iqamaWidth = max(iqamaWidth, DisplayWidth(iqamas[i]))
 		}
 	}
 
 	// Print prayers
 	for i, p := range prayers {
 		status := ""
-		prayerTime, err := parseTimeToday(p.time, now)
-
-		prayerDisplay := fmt.Sprintf("%s %-8s  %s", p.emoji, p.name, p.time)
 
-		if err == nil {
-			if now.After(prayerTime) {
-				status = dim("✓ Passed")
-			} else if i == nextPrayerIdx {
-				mins := int(time.Until(prayerTime).Minutes())
-				status = yellow(fmt.Sprintf("▶ Next prayer in %s", formatMinutes(mins)))
-				prayerDisplay = cyan(prayerDisplay)
+		prayerDisplay := fmt.Sprintf("%s  %s", PadRight(names[i], nameWidth), PadRight(times[i], timeWidth))
+		if icon := th.PrayerIcon(p.Name); icon != "" {
+			prayerDisplay = icon + " " + prayerDisplay
+		}
+		if data.HasIqama() {
+			prayerDisplay = fmt.Sprintf("%s  %s", prayerDisplay, PadRight(iqamas[i], iqamaWidth))
+		}
+		if data.Day.Traveler {
+			rakah := ""
+			if count := prayer.RakahCount(p.Name, true); count > 0 {
+				rakah = l.T("label.rakah", count)
+				if prayer.IsShortened(p.Name) {
+					rakah += " " + l.T("label.qasr")
+				}
 			}
+			prayerDisplay = fmt.Sprintf("%s  %s", prayerDisplay, PadRight(rakah, 16))
+		}
+
+		if now.After(p.Time) {
+			status = dim(th.Prefix("passed") + l.T("status.passed"))
+		} else if next != nil && p.Name == next.Name {
+			mins := int(p.Time.Sub(now).Minutes())
+			status = yellow(th.Prefix("next") + l.T("status.nextPrayer", l.Duration(mins)))
+			prayerDisplay = cyan(prayerDisplay)
 		}
 
 		if status != "" {
@@ -106,23 +110,63 @@ func (f *PrettyFormatter) Format(w io.Writer, data *PrayerData) error {
 			fmt.Fprintf(w, "%s\n", prayerDisplay)
 		}
 	}
+	if line := data.nextTomorrow(l, now); line != "" {
+		fmt.Fprintf(w, "%s\n", yellow(th.Prefix("next")+line))
+	}
 
 	fmt.Fprintln(w)
 
 	// Qibla
 	if data.ShowQibla && data.Qibla != nil {
-		compass := getCompassDirection(data.Qibla.Direction)
-		fmt.Fprintf(w, "🧭 Qibla Direction: %s (%.1f°)\n", green(compass), data.Qibla.Direction)
+		compass := CompassDirection(data.Qibla.Direction)
+		fmt.Fprintf(w, "%s%s\n", th.Prefix("compass"), l.T("label.qiblaDirection", compass, data.Qibla.Direction))
+	}
+
+	// Traveler mode
+	if data.Day.Traveler {
+		fmt.Fprintf(w, "%s%s\n", th.Prefix("traveler"), bold(l.T("traveler.title")))
+		for _, cw := range day.Windows {
+			fmt.Fprintf(w, "   %s\n", l.T("traveler.window",
+				l.Prayer(cw.Name), green(l.Clock(cw.Taqdim)), green(l.Clock(cw.Takhir)), l.Clock(cw.End)))
+		}
+	}
+
+	// Mosque
+	if data.Mosque != "" {
+		fmt.Fprintf(w, "%s%s\n", th.Prefix("mosque"), l.T("label.mosque", data.Mosque))
 	}
 
-	// Du'a placeholder
-	if data.ShowDua {
-		fmt.Fprintf(w, "📖 Today's Du'a: %s\n", dim("\"Allahumma inni as'aluka...\"\n"))
+	// Friday reminders
+	for _, r := range data.Reminders {
+		fmt.Fprintf(w, "%s%s\n", th.Prefix("reminder"), r)
+	}
+
+	// Du'a
+	if data.HasDua() {
+		fmt.Fprintf(w, "%s%s\n", th.Prefix("dua"), bold(l.DuaTitle(data.Dua)))
+		fmt.Fprintf(w, "   %s\n", green(data.Dua.Arabic))
+		fmt.Fprintf(w, "   %s\n", dim(data.Dua.Transliteration))
+		fmt.Fprintf(w, "   \"%s\"\n", data.Dua.Translation)
+		fmt.Fprintf(w, "   %s\n", dim("— "+duaSource(data.Dua)))
+		fmt.Fprintln(w)
 	}
 
 	// Method
-	fmt.Fprintf(w, "⚙️  Method: %s\n", dim(data.Method))
+	method := th.Prefix("method")
+	if method != "" {
+		// The gear emoji renders narrower than other emoji
+		method += " "
+	}
+	fmt.Fprintf(w, "%s%s\n", method, l.T("label.method", dim(data.Method)))
 	fmt.Fprintln(w)
 
 	return nil
 }
+
+// PadRight pads text with spaces to the given display width
+func PadRight(text string, width int) string {
+	if n := DisplayWidth(text); n < width {
+		return text + strings.Repeat(" ", width-n)
+	}
+	return text
+}
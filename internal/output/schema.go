@@ -0,0 +1,138 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"embed"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// SchemaVersion is the version of the envelope and of the schemas printed by pray schema.
+// It is bumped when a field is removed, renamed or changes meaning; new optional fields
+// keep the version.
+const SchemaVersion = 1
+
+// Version is the pray version reported in the envelope's source, set from the build flags
+var Version = "dev"
+
+//go:embed schema/*.schema.json
+var schemaFiles embed.FS
+
+// SchemaNames lists the schemas of the machine-readable formats, see Schema
+func SchemaNames() []string {
+	return []string{"json", "webhook"}
+}
+
+// Schema returns the JSON Schema of a machine-readable format: "json" for the JSON output of
+// all commands, or "webhook" for the webhook format
+func Schema(name string) ([]byte, error) {
+	if !slices.Contains(SchemaNames(), name) {
+		return nil, fmt.Errorf("unknown schema: %s (available: %s)", name, strings.Join(SchemaNames(), ", "))
+	}
+	return schemaFiles.ReadFile("schema/" + name + ".schema.json")
+}
+
+// Envelope wraps machine-readable output with the version of its schema, when and by
+// what it was generated. YAML and XML output use the same envelope, under a pray root
+// element in XML.
+type Envelope struct {
+	XMLName       xml.Name     `json:"-" yaml:"-" xml:"pray"`
+	SchemaVersion int          `json:"schemaVersion" yaml:"schemaVersion" xml:"schemaVersion"`
+	GeneratedAt   string       `json:"generatedAt" yaml:"generatedAt" xml:"generatedAt"` // RFC 3339, UTC
+	Source        SourceOutput `json:"source" yaml:"source" xml:"source"`
+	Data          any          `json:"data" yaml:"data" xml:"data"`
+}
+
+// SourceOutput identifies the program that generated the output and its data provider
+type SourceOutput struct {
+	Name     string `json:"name" yaml:"name" xml:"name"`
+	Version  string `json:"version" yaml:"version" xml:"version"`
+	Provider string `json:"provider" yaml:"provider" xml:"provider"`
+}
+
+// NewEnvelope wraps data in an envelope generated now
+func NewEnvelope(data any) *Envelope {
+	return &Envelope{
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		Source: SourceOutput{
+			Name:     "pray-cli",
+			Version:  Version,
+			Provider: "AlAdhan",
+		},
+		Data: data,
+	}
+}
+
+// NextOutput is the data of the JSON output of pray next: the next prayer, with the
+// pending Iqama and the open combined window when there are any
+type NextOutput struct {
+	NextPrayerOutput
+	PendingIqama   *PendingIqamaOutput `json:"pendingIqama,omitempty"`
+	CombinedWindow *WindowOutput       `json:"combinedWindow,omitempty"`
+	Reminders      []string            `json:"reminders,omitempty"`
+	Dua            *prayer.Dua         `json:"dua,omitempty"`
+	Location       string              `json:"location"`
+}
+
+// PendingIqamaOutput is a prayer whose Adhan has passed and whose Iqama has not
+type PendingIqamaOutput struct {
+	Name         string `json:"name"`
+	Time         string `json:"time"`
+	MinutesUntil int    `json:"minutesUntil"`
+}
+
+// WindowOutput is an open traveler window in which two prayers may be combined
+type WindowOutput struct {
+	Name         string `json:"name"`
+	End          string `json:"end"`
+	MinutesUntil int    `json:"minutesUntil"`
+}
+
+// UpcomingOutput is the data of the JSON output of pray next --count
+type UpcomingOutput struct {
+	Prayers  []UpcomingPrayerOutput `json:"prayers"`
+	Location string                 `json:"location"`
+	Method   string                 `json:"method"`
+}
+
+// UpcomingPrayerOutput is an upcoming prayer, possibly on a following day
+type UpcomingPrayerOutput struct {
+	Name         string `json:"name"`
+	Time         string `json:"time"`
+	Date         string `json:"date"`
+	Day          string `json:"day"`
+	ISO          string `json:"iso"`
+	Iqama        string `json:"iqama,omitempty"`
+	MinutesUntil int    `json:"minutesUntil"`
+}
+
+// WaitOutput is the data of the JSON output of pray wait: the prayer that was waited for
+type WaitOutput struct {
+	Name   string `json:"name"`
+	Time   string `json:"time"`
+	Date   string `json:"date"`
+	ISO    string `json:"iso"`
+	Iqama  string `json:"iqama,omitempty"`
+	Before string `json:"before,omitempty"`
+}
+
+// CountdownOutput is a line of the ndjson output of pray countdown: a "tick" every second,
+// or an "alert" when a threshold before a prayer passes
+type CountdownOutput struct {
+	Event          string              `json:"event"`
+	Now            string              `json:"now"` // RFC 3339, in the location's timezone
+	Next           *NextPrayerOutput   `json:"next,omitempty"`
+	SecondsUntil   int                 `json:"secondsUntil"`
+	Progress       float64             `json:"progress"`               // Through the current prayer window, from 0 to 1
+	AlertMinutes   *int                `json:"alertMinutes,omitempty"` // Threshold of an alert
+	PendingIqama   *PendingIqamaOutput `json:"pendingIqama,omitempty"`
+	CombinedWindow *WindowOutput       `json:"combinedWindow,omitempty"`
+	Estimated      bool                `json:"estimated,omitempty"` // Offline, the day's times are estimated
+	Location       string              `json:"location"`
+}
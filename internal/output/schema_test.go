@@ -0,0 +1,287 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	for _, name := range SchemaNames() {
+		schema, err := Schema(name)
+		if err != nil {
+			t.Fatalf("Schema(%q) error = %v", name, err)
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(schema, &doc); err != nil {
+			t.Fatalf("Schema(%q) is not valid JSON: %v", name, err)
+		}
+		if doc["$schema"] != "https://json-schema.org/draft/2020-12/schema" {
+			t.Errorf("Schema(%q) $schema = %v", name, doc["$schema"])
+		}
+	}
+
+	if _, err := Schema("csv"); err == nil {
+		t.Error("Schema(\"csv\") should fail")
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	env := NewEnvelope(nil)
+	if env.SchemaVersion != SchemaVersion || env.Source.Name != "pray-cli" || env.Source.Version != Version {
+		t.Errorf("NewEnvelope() = %+v", env)
+	}
+	if !strings.HasSuffix(env.GeneratedAt, "Z") {
+		t.Errorf("GeneratedAt = %q, want UTC", env.GeneratedAt)
+	}
+
+	// pray next without an upcoming prayer has null data, which the schema allows
+	encoded, err := json.Marshal(NewEnvelope((*NextOutput)(nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(encoded), `"data":null`) {
+		t.Errorf("envelope without a next prayer = %s, want null data", encoded)
+	}
+}
+
+// TestOutputMatchesSchema validates the JSON output of the formatters and commands
+// against the published schemas
+func TestOutputMatchesSchema(t *testing.T) {
+	format := func(f Formatter, data *PrayerData) any {
+		var buf bytes.Buffer
+		if err := f.Format(&buf, data); err != nil {
+			t.Fatalf("%T.Format() error = %v", f, err)
+		}
+		var doc any
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("%T output is not valid JSON: %v", f, err)
+		}
+		return doc
+	}
+	envelope := func(data any) any {
+		encoded, err := json.Marshal(NewEnvelope(data))
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		var doc any
+		if err := json.Unmarshal(encoded, &doc); err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+
+	next := &NextOutput{
+		NextPrayerOutput: NextPrayerOutput{Name: "Asr", Time: "15:18", Date: "2024-01-15", Iqama: "15:30", MinutesUntil: 42},
+		PendingIqama:     &PendingIqamaOutput{Name: "Dhuhr", Time: "12:20", MinutesUntil: 5},
+		CombinedWindow:   &WindowOutput{Name: "Dhuhr + Asr", End: "17:51", MinutesUntil: 200},
+		Reminders:        []string{"Read Surah al-Kahf"},
+		Dua:              createFullPrayerData().Dua,
+		Location:         `Cairo, "Egypt"`,
+	}
+	upcoming := &UpcomingOutput{
+		Prayers: []UpcomingPrayerOutput{
+			{Name: "Maghrib", Time: "17:51", Date: "2024-01-15", Day: "Monday", ISO: "2024-01-15T17:51:00+02:00", MinutesUntil: 30},
+			{Name: "Fajr", Time: "05:19", Date: "2024-01-16", Day: "Tuesday", ISO: "2024-01-16T05:19:00+02:00", Iqama: "05:35", MinutesUntil: 718},
+		},
+		Location: "Cairo, Egypt",
+		Method:   "Egyptian General Authority of Survey",
+	}
+	alertMinutes := 5
+	tick := &CountdownOutput{
+		Event:        "tick",
+		Now:          "2024-01-15T16:21:30+02:00",
+		Next:         &NextPrayerOutput{Name: "Maghrib", Time: "17:51", Date: "2024-01-15", MinutesUntil: 89},
+		SecondsUntil: 5370,
+		Progress:     0.372,
+		PendingIqama: &PendingIqamaOutput{Name: "Asr", Time: "15:30", MinutesUntil: 0},
+		Estimated:    true,
+		Location:     "Cairo, Egypt",
+	}
+	alert := &CountdownOutput{
+		Event:        "alert",
+		Now:          "2024-01-15T17:46:00+02:00",
+		Next:         &NextPrayerOutput{Name: "Maghrib", Time: "17:51", Date: "2024-01-15", MinutesUntil: 5},
+		SecondsUntil: 300,
+		Progress:     0.95,
+		AlertMinutes: &alertMinutes,
+		Location:     "Cairo, Egypt",
+	}
+	wait := &WaitOutput{Name: "Isha", Time: "19:10", Date: "2024-01-15", ISO: "2024-01-15T19:10:00+02:00", Before: "5m"}
+
+	tests := []struct {
+		name   string
+		schema string
+		doc    any
+	}{
+		{"json", "json", format(&JSONFormatter{}, createTestPrayerData())},
+		{"json with every section", "json", format(&JSONFormatter{}, createFullPrayerData())},
+		{"webhook", "webhook", format(&WebhookFormatter{}, createTestPrayerData())},
+		{"webhook with every section", "webhook", format(&WebhookFormatter{}, createFullPrayerData())},
+		{"next", "json", envelope(next)},
+		{"next without a prayer", "json", envelope((*NextOutput)(nil))},
+		{"next --count", "json", envelope(upcoming)},
+		{"wait", "json", envelope(wait)},
+		{"countdown tick", "json", envelope(tick)},
+		{"countdown alert", "json", envelope(alert)},
+	}
+
+	// Each line of ndjson output is a JSON envelope
+	var buf bytes.Buffer
+	if err := (&NDJSONFormatter{}).FormatRange(&buf, []*PrayerData{createTestPrayerData(), createFullPrayerData()}); err != nil {
+		t.Fatalf("NDJSONFormatter.FormatRange() error = %v", err)
+	}
+	for i, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var doc any
+		if err := json.Unmarshal([]byte(line), &doc); err != nil {
+			t.Fatalf("ndjson line %d is not valid JSON: %v", i+1, err)
+		}
+		tests = append(tests, struct {
+			name   string
+			schema string
+			doc    any
+		}{fmt.Sprintf("ndjson line %d", i+1), "json", doc})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Schema(tt.schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var root map[string]any
+			if err := json.Unmarshal(schema, &root); err != nil {
+				t.Fatal(err)
+			}
+			if err := validateSchema(root, root, tt.doc, ""); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	// The schemas reject what they do not describe
+	schema, _ := Schema("json")
+	var root map[string]any
+	_ = json.Unmarshal(schema, &root)
+	doc := format(&JSONFormatter{}, createTestPrayerData())
+	doc.(map[string]any)["data"].(map[string]any)["unknown"] = true
+	if validateSchema(root, root, doc, "") == nil {
+		t.Error("an unknown field should not match the schema")
+	}
+}
+
+// validateSchema validates a decoded JSON document against the subset of JSON Schema
+// used by the published schemas
+func validateSchema(root, schema map[string]any, doc any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		name, found := strings.CutPrefix(ref, "#/$defs/")
+		def, ok := root["$defs"].(map[string]any)[name].(map[string]any)
+		if !found || !ok {
+			return fmt.Errorf("%s: unresolved $ref %s", path, ref)
+		}
+		return validateSchema(root, def, doc, path)
+	}
+
+	if want, ok := schema["const"]; ok && want != doc {
+		return fmt.Errorf("%s: %v is not %v", path, doc, want)
+	}
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, doc) {
+		return fmt.Errorf("%s: %v is not one of %v", path, doc, enum)
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		matches := 0
+		for _, alt := range oneOf {
+			if validateSchema(root, alt.(map[string]any), doc, path) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return fmt.Errorf("%s: matches %d of the oneOf schemas, want 1", path, matches)
+		}
+	}
+	if typ, ok := schema["type"].(string); ok && !schemaType(typ, doc) {
+		return fmt.Errorf("%s: %v is not of type %s", path, doc, typ)
+	}
+
+	switch v := doc.(type) {
+	case map[string]any:
+		props, _ := schema["properties"].(map[string]any)
+		for _, key := range schemaStrings(schema["required"]) {
+			if _, ok := v[key]; !ok {
+				return fmt.Errorf("%s: missing required %s", path, key)
+			}
+		}
+		for key, child := range v {
+			if prop, ok := props[key].(map[string]any); ok {
+				if err := validateSchema(root, prop, child, path+"/"+key); err != nil {
+					return err
+				}
+				continue
+			}
+			switch extra := schema["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					return fmt.Errorf("%s: unexpected property %s", path, key)
+				}
+			case map[string]any:
+				if err := validateSchema(root, extra, child, path+"/"+key); err != nil {
+					return err
+				}
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				if err := validateSchema(root, items, item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(v) {
+			return fmt.Errorf("%s: %q does not match %s", path, v, pattern)
+		}
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok && v < min {
+			return fmt.Errorf("%s: %v is below %v", path, v, min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && v > max {
+			return fmt.Errorf("%s: %v is above %v", path, v, max)
+		}
+	}
+	return nil
+}
+
+// schemaType reports whether a decoded JSON value has a JSON Schema type
+func schemaType(typ string, doc any) bool {
+	switch v := doc.(type) {
+	case map[string]any:
+		return typ == "object"
+	case []any:
+		return typ == "array"
+	case string:
+		return typ == "string"
+	case bool:
+		return typ == "boolean"
+	case float64:
+		return typ == "number" || (typ == "integer" && v == float64(int64(v)))
+	case nil:
+		return typ == "null"
+	}
+	return false
+}
+
+// schemaStrings returns a decoded JSON array of strings
+func schemaStrings(v any) []string {
+	var out []string
+	list, _ := v.([]any)
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
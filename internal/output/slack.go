@@ -3,9 +3,13 @@ package output
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 // SlackFormatter formats output as Slack Block Kit JSON
@@ -39,48 +43,36 @@ type SlackElement struct {
 
 // Format writes the prayer times as Slack Block Kit JSON
 func (f *SlackFormatter) Format(w io.Writer, data *PrayerData) error {
-	if data.Response == nil {
-		return fmt.Errorf("no prayer times data")
+	if data.Response == nil || data.Day == nil {
+		return errors.New(data.localizer().T("error.noData"))
 	}
 
-	resp := data.Response
-	timings := resp.Data.Timings
-	date := resp.Data.Date
-	meta := resp.Data.Meta
+	date := data.Response.Data.Date
+	l := data.localizer()
 
-	// Get current time for next prayer calculation
-	now := time.Now()
-	tz := meta.Timezone
-	if tz != "" {
-		loc, err := time.LoadLocation(tz)
-		if err == nil {
-			now = time.Now().In(loc)
+	// Prayers without Midnight, and the next one
+	var prayers []prayer.Event
+	for _, e := range data.Day.Events {
+		if e.Name != "Midnight" {
+			prayers = append(prayers, e)
 		}
 	}
-
-	prayers := []struct {
-		name string
-		time string
-	}{
-		{"Fajr", cleanTime(timings.Fajr)},
-		{"Sunrise", cleanTime(timings.Sunrise)},
-		{"Dhuhr", cleanTime(timings.Dhuhr)},
-		{"Asr", cleanTime(timings.Asr)},
-		{"Maghrib", cleanTime(timings.Maghrib)},
-		{"Isha", cleanTime(timings.Isha)},
+	var nextPrayer time.Time
+	if next := data.Day.Next(data.now()); next != nil {
+		nextPrayer = next.Time
 	}
 
-	// Find next prayer
-	nextPrayer := ""
-	for _, p := range prayers {
-		prayerTime, err := parseTimeToday(p.time, now)
-		if err != nil {
-			continue
-		}
-		if now.Before(prayerTime) {
-			nextPrayer = p.name
-			break
-		}
+	context := []SlackElement{
+		{
+			Type: "mrkdwn",
+			Text: l.T("label.method", data.Method),
+		},
+	}
+	if data.Mosque != "" {
+		context = append(context, SlackElement{
+			Type: "mrkdwn",
+			Text: l.T("label.mosque", data.Mosque),
+		})
 	}
 
 	message := SlackMessage{
@@ -89,7 +81,7 @@ func (f *SlackFormatter) Format(w io.Writer, data *PrayerData) error {
 				Type: "header",
 				Text: &SlackText{
 					Type:  "plain_text",
-					Text:  fmt.Sprintf("🕌 Prayer Times - %s", data.Location),
+					Text:  "🕌 " + l.T("heading.title", data.Location),
 					Emoji: true,
 				},
 			},
@@ -97,7 +89,7 @@ func (f *SlackFormatter) Format(w io.Writer, data *PrayerData) error {
 				Type: "section",
 				Text: &SlackText{
 					Type: "mrkdwn",
-					Text: fmt.Sprintf("📅 *%s*", date.Readable),
+					Text: fmt.Sprintf("📅 *%s*", l.Date(date)),
 				},
 			},
 			{
@@ -109,29 +101,55 @@ func (f *SlackFormatter) Format(w io.Writer, data *PrayerData) error {
 					fields := make([]SlackText, 0)
 					for _, p := range prayers {
 						indicator := ""
-						if p.name == nextPrayer {
+						if p.Time.Equal(nextPrayer) {
 							indicator = " ▶️"
 						}
+						iqama := ""
+						if p.HasIqama() {
+							iqama = fmt.Sprintf(" _(%s)_", l.T("label.iqama", l.Time(p.Iqama)))
+						}
 						fields = append(fields, SlackText{
 							Type: "mrkdwn",
-							Text: fmt.Sprintf("*%s:*\n%s%s", p.name, p.time, indicator),
+							Text: fmt.Sprintf("*%s:*\n%s%s%s", l.Prayer(p.Name), l.Time(p.Time), iqama, indicator),
 						})
 					}
 					return fields
 				}(),
 			},
 			{
-				Type: "context",
-				Elements: []SlackElement{
-					{
-						Type: "mrkdwn",
-						Text: fmt.Sprintf("Method: %s", data.Method),
-					},
-				},
+				Type:     "context",
+				Elements: context,
 			},
 		},
 	}
 
+	if data.HasDua() {
+		dua := SlackBlock{
+			Type: "section",
+			Text: &SlackText{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf("📖 *%s*\n>%s\n>_%s_\n>\"%s\"\n— %s",
+					l.DuaTitle(data.Dua), data.Dua.Arabic, data.Dua.Transliteration, data.Dua.Translation, duaSource(data.Dua)),
+			},
+		}
+		// Insert before the context block
+		last := len(message.Blocks) - 1
+		message.Blocks = append(message.Blocks[:last], dua, message.Blocks[last])
+	}
+
+	if len(data.Reminders) > 0 {
+		reminders := SlackBlock{
+			Type: "section",
+			Text: &SlackText{
+				Type: "mrkdwn",
+				Text: "📿 " + strings.Join(data.Reminders, "\n📿 "),
+			},
+		}
+		// Insert before the context block
+		last := len(message.Blocks) - 1
+		message.Blocks = append(message.Blocks[:last], reminders, message.Blocks[last])
+	}
+
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(message)
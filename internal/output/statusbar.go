@@ -0,0 +1,207 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// WaybarFormatter formats the next prayer as a waybar custom module (JSON with text,
+// tooltip and class)
+type WaybarFormatter struct{}
+
+// I3barFormatter formats the next prayer as an i3bar block, also read by i3blocks
+// with format=json
+type I3barFormatter struct{}
+
+// PolybarFormatter formats the next prayer as a polybar script module line
+type PolybarFormatter struct{}
+
+// TmuxFormatter formats the next prayer for the tmux status line
+type TmuxFormatter struct{}
+
+// Urgency of the next prayer in status bar output: "warning" from StatusWarning before
+// it, "critical" from StatusCritical before it, "normal" otherwise
+const (
+	StatusWarning  = 15 * time.Minute
+	StatusCritical = 5 * time.Minute
+)
+
+// Colors of the warning and critical urgencies in i3bar, polybar and tmux output
+const (
+	statusWarningColor  = "#f0c674"
+	statusCriticalColor = "#cc6666"
+)
+
+// StatusFormats lists the status bar formats
+var StatusFormats = []string{"waybar", "i3bar", "polybar", "tmux"}
+
+// IsStatusFormat reports whether format is a status bar format, which is meant to be run
+// every few seconds and reads prayer times from the cache only
+func IsStatusFormat(format string) bool {
+	return slices.Contains(StatusFormats, format)
+}
+
+// status is the next prayer and its countdown, shared by the status bar formats
+type status struct {
+	Text     string // Prayer, time and countdown, e.g. "Asr 15:12 (1h 2m)"
+	Short    string // Prayer and countdown, e.g. "Asr 1h 2m"
+	Prayer   string // Lowercase prayer name, e.g. "asr", for icons and styles
+	Urgency  string // "normal", "warning" or "critical"
+	Progress int    // Percent of the current prayer window that has passed
+	Tooltip  string // The day's prayers, one per line, and the location
+}
+
+// buildStatus returns the status of the next prayer
+func buildStatus(data *PrayerData) (*status, error) {
+	if data == nil || data.Response == nil || data.Day == nil {
+		return nil, errors.New("no prayer times data")
+	}
+	l := data.localizer()
+	day := data.Day
+	now := data.now()
+
+	// Without a next prayer the text is empty, which hides the module in most bars
+	s := &status{Urgency: "normal", Tooltip: statusTooltip(data, l, now)}
+	next := day.NextPrayer(now)
+	if next == nil {
+		return s, nil
+	}
+
+	until := next.Time.Sub(now)
+	countdown := l.Duration(int(until.Minutes()))
+	s.Text = fmt.Sprintf("%s %s (%s)", l.Prayer(next.Name), l.Time(next.Time), countdown)
+	s.Short = l.Prayer(next.Name) + " " + countdown
+	s.Prayer = strings.ToLower(strings.ReplaceAll(next.Name, "'", ""))
+	if i := strings.IndexAny(s.Prayer, " +"); i > 0 {
+		s.Prayer = s.Prayer[:i] // Jumu'ah 2 or Dhuhr + Asr in traveler mode
+	}
+	switch {
+	case until <= StatusCritical:
+		s.Urgency = "critical"
+	case until <= StatusWarning:
+		s.Urgency = "warning"
+	}
+	if start, _ := day.Window(now); start != nil {
+		s.Progress = int(prayer.Progress(start.Time, next.Time, now) * 100)
+	}
+	return s, nil
+}
+
+// statusTooltip lists the day's prayers with their Iqama times, marking the next one
+func statusTooltip(data *PrayerData, l *Localizer, now time.Time) string {
+	day := data.Day
+	next := day.Next(now)
+
+	var lines []string
+	for _, e := range day.Events {
+		line := l.Prayer(e.Name) + "  " + l.Time(e.Time)
+		if e.HasIqama() {
+			line += "  " + l.T("label.iqama", l.Time(e.Iqama))
+		}
+		if next != nil && e.Name == next.Name && e.Time.Equal(next.Time) {
+			line = "▸ " + line
+		}
+		lines = append(lines, line)
+	}
+	if data.Location != "" {
+		lines = append(lines, data.Location)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Format writes the next prayer as a line of waybar JSON. The class is the urgency, and
+// alt is the prayer for format-icons.
+func (f *WaybarFormatter) Format(w io.Writer, data *PrayerData) error {
+	s, err := buildStatus(data)
+	if err != nil {
+		return err
+	}
+	return writeStatusJSON(w, struct {
+		Text       string `json:"text"`
+		Alt        string `json:"alt"`
+		Tooltip    string `json:"tooltip"`
+		Class      string `json:"class"`
+		Percentage int    `json:"percentage"`
+	}{s.Text, s.Prayer, s.Tooltip, s.Urgency, s.Progress})
+}
+
+// Format writes the next prayer as an i3bar block, urgent from StatusCritical before it
+func (f *I3barFormatter) Format(w io.Writer, data *PrayerData) error {
+	s, err := buildStatus(data)
+	if err != nil {
+		return err
+	}
+	block := struct {
+		Name      string `json:"name"`
+		Instance  string `json:"instance,omitempty"`
+		FullText  string `json:"full_text"`
+		ShortText string `json:"short_text"`
+		Color     string `json:"color,omitempty"`
+		Urgent    bool   `json:"urgent,omitempty"`
+	}{Name: "pray", Instance: s.Prayer, FullText: s.Text, ShortText: s.Short, Urgent: s.Urgency == "critical"}
+	if !data.NoColor {
+		block.Color = statusColor(s.Urgency)
+	}
+	return writeStatusJSON(w, block)
+}
+
+// Format writes the next prayer as a line of text with polybar color tags
+func (f *PolybarFormatter) Format(w io.Writer, data *PrayerData) error {
+	s, err := buildStatus(data)
+	if err != nil {
+		return err
+	}
+	text := s.Text
+	if color := statusColor(s.Urgency); color != "" && !data.NoColor {
+		text = "%{F" + color + "}" + text + "%{F-}"
+	}
+	_, err = fmt.Fprintln(w, text)
+	return err
+}
+
+// Format writes the next prayer as a line of text with tmux style attributes
+func (f *TmuxFormatter) Format(w io.Writer, data *PrayerData) error {
+	s, err := buildStatus(data)
+	if err != nil {
+		return err
+	}
+	text := strings.ReplaceAll(s.Text, "#", "##")
+	if color := statusColor(s.Urgency); color != "" && !data.NoColor {
+		style := "fg=" + color
+		if s.Urgency == "critical" {
+			style += ",bold"
+		}
+		text = "#[" + style + "]" + text + "#[default]"
+	}
+	_, err = fmt.Fprintln(w, text)
+	return err
+}
+
+// statusColor returns the color of an urgency, or "" for the bar's own color
+func statusColor(urgency string) string {
+	switch urgency {
+	case "critical":
+		return statusCriticalColor
+	case "warning":
+		return statusWarningColor
+	}
+	return ""
+}
+
+// writeStatusJSON writes v as a single line of JSON, as status bars read a line per update
+func writeStatusJSON(w io.Writer, v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return err
+}
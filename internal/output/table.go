@@ -2,14 +2,17 @@
 package output
 
 import (
+	"errors"
 	"fmt"
 	"io"
-	"os"
 	"strings"
-	"time"
+	"unicode"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
 )
 
 // TableFormatter formats output as an ASCII table
@@ -17,13 +20,12 @@ type TableFormatter struct{}
 
 // Format writes the prayer times as a table
 func (f *TableFormatter) Format(w io.Writer, data *PrayerData) error {
-	if data.Response == nil {
-		return fmt.Errorf("no prayer times data")
+	if data.Response == nil || data.Day == nil {
+		return errors.New(data.localizer().T("error.noData"))
 	}
 
-	resp := data.Response
-	timings := resp.Data.Timings
-	date := resp.Data.Date
+	date := data.Response.Data.Date
+	day := data.Day
 
 	// Colors
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -35,104 +37,199 @@ func (f *TableFormatter) Format(w io.Writer, data *PrayerData) error {
 		color.NoColor = true
 	}
 
+	th := data.theme()
+	if th.Plain {
+		return formatPlain(w, data)
+	}
+
+	l := data.localizer()
+	row := func(text string) string {
+		return th.boxRowAligned(" "+text+" ", 50, l.IsRTL())
+	}
+	v := th.Box.Vertical
+
 	// Header
 	fmt.Fprintln(w)
-	fmt.Fprintf(w, "┌──────────────────────────────────────────────────┐\n")
-	fmt.Fprintf(w, "│%s│\n", centerText(fmt.Sprintf("Prayer Times - %s", data.Location), 50))
-	fmt.Fprintf(w, "│%s│\n", centerText(date.Readable, 50))
+	fmt.Fprintln(w, th.line(th.Box.TopLeft, th.Box.TopRight, 50))
+	fmt.Fprintln(w, v+centerText(l.T("heading.title", data.Location), 50)+v)
+	fmt.Fprintln(w, v+centerText(l.Date(date), 50)+v)
 
 	if data.ShowHijri && data.HijriFormat != "none" {
-		hijri := date.Hijri
-		hijriStr := fmt.Sprintf("%s %s %s", hijri.Day, hijri.Month.En, hijri.Year)
-		fmt.Fprintf(w, "│%s│\n", centerText(hijriStr, 50))
-	}
-
-	// Create prayers list with status
-	prayers := []struct {
-		name  string
-		time  string
-		emoji string
-	}{
-		{"Fajr", cleanTime(timings.Fajr), "🌅"},
-		{"Sunrise", cleanTime(timings.Sunrise), "🌄"},
-		{"Dhuhr", cleanTime(timings.Dhuhr), "☀️"},
-		{"Asr", cleanTime(timings.Asr), "🌤️"},
-		{"Maghrib", cleanTime(timings.Maghrib), "🌆"},
-		{"Isha", cleanTime(timings.Isha), "🌙"},
-		{"Midnight", cleanTime(timings.Midnight), "🌃"},
-	}
-
-	// Get current time for status
-	now := time.Now()
-	tz := resp.Data.Meta.Timezone
-	if tz != "" {
-		loc, err := time.LoadLocation(tz)
-		if err == nil {
-			now = time.Now().In(loc)
-		}
+		fmt.Fprintln(w, v+centerText(l.Hijri(date.Hijri), 50)+v)
 	}
 
-	// Find next prayer
-	var nextPrayerIdx int = -1
-	for i, p := range prayers {
-		prayerTime, err := parseTimeToday(p.time, now)
-		if err != nil {
-			continue
-		}
-		if now.Before(prayerTime) {
-			nextPrayerIdx = i
-			break
-		}
-	}
+	// Current time for status
+	now := data.now()
+	next := day.Next(now)
 
 	// Table
-	table := tablewriter.NewTable(os.Stdout)
-	table.Header("Prayer", "Time", "Status")
+	options := []tablewriter.Option{tablewriter.WithSymbols(th.Symbols())}
+	if l.IsRTL() {
+		options = append(options,
+			tablewriter.WithHeaderAlignment(tw.AlignRight),
+			tablewriter.WithRowAlignment(tw.AlignRight))
+	}
+	table := tablewriter.NewTable(w, options...)
+	header := []any{l.T("column.prayer"), l.T("column.time")}
+	if data.HasIqama() {
+		header = append(header, l.T("column.iqama"))
+	}
+	if data.Day.Traveler {
+		header = append(header, l.T("column.rakahs"))
+	}
+	table.Header(rtlColumns(append(header, l.T("column.status")), l.IsRTL())...)
 
-	for i, p := range prayers {
+	for _, p := range day.Events {
 		status := ""
-		prayerName := p.name
-		prayerTime := p.time
-
-		prayerDateTime, err := parseTimeToday(p.time, now)
-		if err == nil {
-			if now.After(prayerDateTime) {
-				status = dim("✓ Passed")
-			} else if i == nextPrayerIdx {
-				mins := int(time.Until(prayerDateTime).Minutes())
-				status = yellow(fmt.Sprintf("▶ Next (in %s)", formatMinutes(mins)))
-				prayerName = cyan(p.name)
-				prayerTime = green(p.time)
-			}
+		prayerName := l.Prayer(p.Name)
+		prayerTime := l.Time(p.Time)
+
+		if now.After(p.Time) {
+			status = dim(th.Prefix("passed") + l.T("status.passed"))
+		} else if next != nil && p.Name == next.Name {
+			mins := int(p.Time.Sub(now).Minutes())
+			status = yellow(th.Prefix("next") + l.T("status.next", l.Duration(mins)))
+			prayerName = cyan(prayerName)
+			prayerTime = green(prayerTime)
 		}
 
-		table.Append(prayerName, prayerTime, status)
+		cells := []any{prayerName, prayerTime}
+		if data.HasIqama() {
+			iqama := ""
+			if p.HasIqama() {
+				iqama = l.Time(p.Iqama)
+			}
+			cells = append(cells, iqama)
+		}
+		if data.Day.Traveler {
+			cells = append(cells, rakahLabel(l, p.Name))
+		}
+		table.Append(rtlColumns(append(cells, status), l.IsRTL())...)
 	}
 
-	fmt.Fprintln(w, "├──────────────────────────────────────────────────┤")
-	table.Render()
+	divider := th.line(th.Box.LeftT, th.Box.RightT, 50)
+	fmt.Fprintln(w, divider)
+	if err := table.Render(); err != nil {
+		return err
+	}
 
 	// Footer with Qibla and Method
-	fmt.Fprintf(w, "├──────────────────────────────────────────────────┤\n")
+	fmt.Fprintln(w, divider)
+	if line := data.nextTomorrow(l, now); line != "" {
+		fmt.Fprintln(w, row(yellow(th.Prefix("next")+line)))
+	}
 	if data.ShowQibla && data.Qibla != nil {
-		compass := getCompassDirection(data.Qibla.Direction)
-		fmt.Fprintf(w, "│ Qibla: %.1f° (%s)%s│\n",
-			data.Qibla.Direction, compass,
-			strings.Repeat(" ", 50-len(fmt.Sprintf(" Qibla: %.1f° (%s)", data.Qibla.Direction, compass))-1))
+		compass := CompassDirection(data.Qibla.Direction)
+		fmt.Fprintln(w, row(l.T("label.qibla", data.Qibla.Direction, compass)))
+	}
+	if data.Mosque != "" {
+		fmt.Fprintln(w, row(l.T("label.mosque", data.Mosque)))
 	}
-	fmt.Fprintf(w, "│ Method: %s%s│\n", data.Method, strings.Repeat(" ", 50-len(fmt.Sprintf(" Method: %s", data.Method))-1))
-	fmt.Fprintf(w, "└──────────────────────────────────────────────────┘\n")
+	for _, r := range data.Reminders {
+		fmt.Fprintln(w, row(r))
+	}
+	if data.Day.Traveler {
+		for _, cw := range day.Windows {
+			fmt.Fprintln(w, row(l.T("traveler.jam", l.Prayer(cw.Name), l.Clock(cw.Taqdim), l.Clock(cw.End), l.Clock(cw.Takhir))))
+		}
+	}
+	fmt.Fprintln(w, row(l.T("label.method", data.Method)))
+	if data.HasDua() {
+		fmt.Fprintln(w, divider)
+		fmt.Fprintln(w, row(l.DuaTitle(data.Dua)+":"))
+		for _, line := range WrapText(data.Dua.Arabic, 48) {
+			fmt.Fprintln(w, th.boxRowAligned(" "+line+" ", 50, true))
+		}
+		for _, line := range WrapText(fmt.Sprintf("\"%s\"", data.Dua.Translation), 48) {
+			fmt.Fprintln(w, th.boxRow(" "+line, 50))
+		}
+		fmt.Fprintln(w, row("— "+duaSource(data.Dua)))
+	}
+	fmt.Fprintln(w, th.line(th.Box.BottomLeft, th.Box.BottomRight, 50))
 
 	return nil
 }
 
 // centerText centers text within a given width
 func centerText(text string, width int) string {
-	if len(text) >= width {
-		return text[:width]
+	n := DisplayWidth(text)
+	if n >= width {
+		return string([]rune(text)[:width])
+	}
+	padding := (width - n) / 2
+	return strings.Repeat(" ", padding) + text + strings.Repeat(" ", width-padding-n)
+}
+
+// boxRow left-aligns text inside a box row of the given inner width
+func (t *Theme) boxRow(text string, width int) string {
+	v := t.Box.Vertical
+	n := DisplayWidth(text)
+	if n >= width {
+		return v + text + v
+	}
+	return v + text + strings.Repeat(" ", width-n) + v
+}
+
+// boxRowAligned is boxRow with right alignment for right-to-left languages
+func (t *Theme) boxRowAligned(text string, width int, rtl bool) string {
+	n := DisplayWidth(text)
+	if !rtl || n >= width {
+		return t.boxRow(strings.TrimSuffix(text, " "), width)
+	}
+	return t.Box.Vertical + strings.Repeat(" ", width-n) + text + t.Box.Vertical
+}
+
+// rtlColumns reverses the column order for right-to-left languages
+func rtlColumns(cells []any, rtl bool) []any {
+	if !rtl {
+		return cells
+	}
+	reversed := make([]any, len(cells))
+	for i, c := range cells {
+		reversed[len(cells)-1-i] = c
+	}
+	return reversed
+}
+
+// DisplayWidth returns the number of terminal columns used by text,
+// ignoring combining marks such as Arabic diacritics
+func DisplayWidth(text string) int {
+	n := 0
+	for _, r := range text {
+		if unicode.Is(unicode.Mn, r) || r == '\u200f' || r == '\u200e' {
+			continue
+		}
+		n++
 	}
-	padding := (width - len(text)) / 2
-	return strings.Repeat(" ", padding) + text + strings.Repeat(" ", width-padding-len(text))
+	return n
+}
+
+// WrapText splits text into lines of at most width columns on word boundaries
+func WrapText(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && DisplayWidth(line)+1+DisplayWidth(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// duaSource returns the source reference of a du'a with its recommended repetitions
+func duaSource(d *prayer.Dua) string {
+	if d.Repeat > 1 {
+		return fmt.Sprintf("%s (×%d)", d.Source, d.Repeat)
+	}
+	return d.Source
 }
 
 // cleanTime removes timezone info from time string (e.g., "05:23 (EET)" -> "05:23")
@@ -144,31 +241,13 @@ func cleanTime(timeStr string) string {
 	return timeStr
 }
 
-// parseTimeToday parses a time string (HH:MM) and returns it as time.Time for today
-func parseTimeToday(timeStr string, now time.Time) (time.Time, error) {
-	var hour, minute int
-	_, err := fmt.Sscanf(timeStr, "%d:%d", &hour, &minute)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location()), nil
-}
-
 // formatMinutes formats minutes into a human-readable string
 func formatMinutes(mins int) string {
-	if mins < 60 {
-		return fmt.Sprintf("%d min", mins)
-	}
-	hours := mins / 60
-	remaining := mins % 60
-	if remaining == 0 {
-		return fmt.Sprintf("%dh", hours)
-	}
-	return fmt.Sprintf("%dh %dm", hours, remaining)
+	return NewLocalizer("en", false).Duration(mins)
 }
 
-// getCompassDirection converts degrees to compass direction
-func getCompassDirection(degrees float64) string {
+// CompassDirection converts degrees to a 16-point compass direction (e.g., "NE")
+func CompassDirection(degrees float64) string {
 	// Normalize to 0-360
 	for degrees < 0 {
 		degrees += 360
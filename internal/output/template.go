@@ -0,0 +1,206 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// TemplateFormatter formats output with the Go text/template in PrayerData.Template,
+// executed against TemplateData
+type TemplateFormatter struct{}
+
+// TemplateData is the normalized day a template is executed against
+type TemplateData struct {
+	Date      time.Time        // Midnight of the day, in its timezone
+	Now       time.Time        // Current time in the day's timezone
+	Hijri     prayer.HijriDate // Empty when the API returned no Hijri date
+	Location  string
+	Latitude  float64
+	Longitude float64
+	Timezone  string
+	Method    string
+	Mosque    string
+	Prayers   []TemplateEvent // The day's events, including Sunrise and Midnight
+	Next      *TemplateEvent  // Next prayer, possibly tomorrow's Fajr; nil when unknown
+	Qibla     *QiblaOutput
+	Jumuah    []string // Jumu'ah khutbah times (HH:MM) on Fridays
+	Reminders []string
+	Dua       *prayer.Dua
+	Traveler  bool
+}
+
+// TemplateEvent is a prayer time as seen by a template
+type TemplateEvent struct {
+	Name     string        // English name, e.g. "Fajr"
+	Label    string        // Localized name
+	Time     time.Time     // Adhan time
+	Iqama    time.Time     // Iqama time, zero when the prayer has none
+	Passed   bool          // The time is before Now
+	IsNext   bool          // This is the next prayer
+	Tomorrow bool          // The time falls on the following day
+	Until    time.Duration // Time from Now, negative once passed
+	In       string        // Localized countdown (e.g., "1h 37m"), "" once passed
+}
+
+// Prayer returns the event with the given English name, or nil when the day has none
+func (d *TemplateData) Prayer(name string) *TemplateEvent {
+	for i := range d.Prayers {
+		if strings.EqualFold(d.Prayers[i].Name, name) {
+			return &d.Prayers[i]
+		}
+	}
+	return nil
+}
+
+// Format executes the data's template and writes the result, ending with a newline
+func (f *TemplateFormatter) Format(w io.Writer, data *PrayerData) error {
+	if data.Response == nil || data.Day == nil {
+		return errors.New(data.localizer().T("error.noData"))
+	}
+	if data.Template == "" {
+		return errors.New("no template given: use --template or --template-file")
+	}
+
+	l := data.localizer()
+	tmpl, err := template.New("output").Funcs(TemplateFuncs(l)).Option("missingkey=error").Parse(data.Template)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data.templateData(l)); err != nil {
+		return err
+	}
+	out := b.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
+
+// templateData builds the normalized day passed to templates
+func (d *PrayerData) templateData(l *Localizer) *TemplateData {
+	day := d.Day
+	now := d.now()
+	meta := d.Response.Data.Meta
+
+	data := &TemplateData{
+		Date:      day.Date,
+		Now:       now,
+		Location:  d.Location,
+		Latitude:  meta.Latitude,
+		Longitude: meta.Longitude,
+		Timezone:  meta.Timezone,
+		Method:    day.Method,
+		Mosque:    d.Mosque,
+		Reminders: d.Reminders,
+		Traveler:  day.Traveler,
+	}
+	for _, e := range day.Jumuah() {
+		data.Jumuah = append(data.Jumuah, e.Clock())
+	}
+	if d.ShowHijri && d.HijriFormat != "none" {
+		data.Hijri = day.Hijri
+	}
+	if d.HasDua() {
+		data.Dua = d.Dua
+	}
+	if d.Qibla != nil {
+		data.Qibla = &QiblaOutput{
+			Direction: d.Qibla.Direction,
+			Compass:   CompassDirection(d.Qibla.Direction),
+		}
+	}
+
+	// Traveler days announce the combined prayer (e.g., "Dhuhr + Asr"), as pray next does,
+	// and mark its first prayer as next
+	next := day.NextPrayer(now)
+	event := func(e prayer.Event) TemplateEvent {
+		te := TemplateEvent{
+			Name:     e.Name,
+			Label:    l.Prayer(e.Name),
+			Time:     e.Time,
+			Iqama:    e.Iqama,
+			Passed:   e.Time.Before(now),
+			IsNext:   next != nil && e.Time.Equal(next.Time) && (e.Name == next.Name || strings.HasPrefix(next.Name, e.Name+" + ")),
+			Tomorrow: day.IsLaterDate(e.Time),
+			Until:    e.Time.Sub(now),
+		}
+		if !te.Passed {
+			te.In = l.Duration(int(te.Until.Minutes()))
+		}
+		return te
+	}
+	for _, e := range day.Events {
+		data.Prayers = append(data.Prayers, event(e))
+	}
+	if next != nil {
+		te := event(*next)
+		data.Next = &te
+	}
+	return data
+}
+
+// TemplateFuncs returns the helper functions available to output templates, formatting
+// with the given localizer:
+//
+//	clock     time of day with the configured clock, e.g. {{clock .Next.Time}}
+//	format    time with a Go layout, e.g. {{format "Mon 02 Jan" .Date}}
+//	date      localized Gregorian date with weekday
+//	hijri     localized Hijri date, e.g. {{hijri .Hijri}}
+//	duration  short duration, e.g. {{duration .Next.Until}} -> "1h 37m"
+//	short     compact duration with h/m units in every language, for prompts,
+//	          e.g. {{short .Next.Until}} -> "1h37m" or "42m"
+//	minutes   whole minutes of a duration
+//	prayer    localized prayer name
+//	digits    number with the configured numerals
+//	pad       pad right to a display width, e.g. {{pad 8 .Name}}
+//	padLeft   pad left to a display width
+//	upper, lower, join, trim
+func TemplateFuncs(l *Localizer) template.FuncMap {
+	return template.FuncMap{
+		"clock": l.Time,
+		"format": func(layout string, t time.Time) string {
+			return t.Format(layout)
+		},
+		"date":  l.DateOf,
+		"hijri": l.HijriDate,
+		"duration": func(d time.Duration) string {
+			return l.Duration(int(d.Minutes()))
+		},
+		"short": func(d time.Duration) string {
+			mins := max(int(d.Minutes()), 0)
+			if mins < 60 {
+				return l.Digits(fmt.Sprintf("%dm", mins))
+			}
+			return l.Digits(fmt.Sprintf("%dh%02dm", mins/60, mins%60))
+		},
+		"minutes": func(d time.Duration) int {
+			return int(d.Minutes())
+		},
+		"prayer": l.Prayer,
+		"digits": func(v interface{}) string {
+			return l.Digits(fmt.Sprint(v))
+		},
+		"pad": func(width int, s string) string {
+			return s + strings.Repeat(" ", max(width-DisplayWidth(s), 0))
+		},
+		"padLeft": func(width int, s string) string {
+			return strings.Repeat(" ", max(width-DisplayWidth(s), 0)) + s
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join": func(sep string, elems []string) string {
+			return strings.Join(elems, sep)
+		},
+		"trim": strings.TrimSpace,
+	}
+}
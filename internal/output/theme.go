@@ -0,0 +1,234 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/olekukonko/tablewriter/tw"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// Theme holds the icons and box drawing characters used to decorate output
+type Theme struct {
+	Name  string
+	Plain bool // Screen-reader friendly: no box drawing, icons or column layout
+	Box   BoxChars
+	icons map[string]string
+}
+
+// BoxChars are the characters used to draw boxes and rules
+type BoxChars struct {
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	LeftT       string
+	RightT      string
+	Horizontal  string
+	Vertical    string
+	Rule        string
+	BarFull     string         // Filled part of a progress bar
+	BarEmpty    string         // Empty part of a progress bar
+	Dot         string         // Dotted lines, e.g., the ring of a compass
+	Spinner     string         // Frames of a spinner animation, one character each
+	Style       tw.BorderStyle // Matching tablewriter border style
+}
+
+var unicodeBox = BoxChars{
+	TopLeft: "┌", TopRight: "┐", BottomLeft: "└", BottomRight: "┘",
+	LeftT: "├", RightT: "┤", Horizontal: "─", Vertical: "│", Rule: "━",
+	BarFull: "█", BarEmpty: "░", Dot: "·", Spinner: "⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏",
+	Style: tw.StyleLight,
+}
+
+var asciiBox = BoxChars{
+	TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+	LeftT: "+", RightT: "+", Horizontal: "-", Vertical: "|", Rule: "=",
+	BarFull: "#", BarEmpty: "-", Dot: ".", Spinner: `|/-\`,
+	Style: tw.StyleASCII,
+}
+
+// Available themes
+var (
+	// ThemeEmoji decorates output with emoji and Unicode box drawing
+	ThemeEmoji = &Theme{
+		Name: "emoji",
+		Box:  unicodeBox,
+		icons: map[string]string{
+			"Fajr":     "🌅",
+			"Sunrise":  "🌄",
+			"Dhuhr":    "☀️",
+			"Asr":      "🌤️",
+			"Maghrib":  "🌆",
+			"Isha":     "🌙",
+			"Midnight": "🌃",
+			"Jumu'ah":  "🕌",
+			"mosque":   "🕌",
+			"calendar": "📅",
+			"compass":  "🧭",
+			"traveler": "🧳",
+			"reminder": "📿",
+			"dua":      "📖",
+			"method":   "⚙️",
+			"location": "📍",
+			"timer":    "⏱️",
+			"clock":    "🕐",
+			"night":    "🌙",
+			"compare":  "📊",
+			"cache":    "📦",
+			"globe":    "🌍",
+			"methods":  "📐",
+			"wave":     "👋",
+			"passed":   "✓",
+			"next":     "▶",
+			"ok":       "✓",
+			"warn":     "⚠",
+			"error":    "❌",
+			"backup":   "📋",
+			"repair":   "🔧",
+			"search":   "🔍",
+			"done":     "✨",
+		},
+	}
+
+	// ThemeUnicode uses Unicode symbols and box drawing without emoji
+	ThemeUnicode = &Theme{
+		Name: "unicode",
+		Box:  unicodeBox,
+		icons: map[string]string{
+			"passed": "✓",
+			"next":   "▶",
+			"ok":     "✓",
+			"warn":   "⚠",
+			"error":  "✗",
+		},
+	}
+
+	// ThemeASCII uses only ASCII characters
+	ThemeASCII = &Theme{
+		Name: "ascii",
+		Box:  asciiBox,
+		icons: map[string]string{
+			"passed": "-",
+			"next":   ">",
+			"ok":     "*",
+			"warn":   "!",
+			"error":  "x",
+		},
+	}
+
+	// ThemePlain is for screen readers: plain sentences with no decoration
+	ThemePlain = &Theme{
+		Name:  "plain",
+		Plain: true,
+		Box:   asciiBox,
+		icons: map[string]string{},
+	}
+)
+
+// ThemeNames returns the names accepted by GetTheme, including "auto"
+func ThemeNames() []string {
+	return []string{"auto", ThemeEmoji.Name, ThemeUnicode.Name, ThemeASCII.Name, ThemePlain.Name}
+}
+
+// GetTheme returns the named theme, resolving "auto" (or "") for stdout.
+// It returns nil for an unknown name.
+func GetTheme(name string, noEmoji bool) *Theme {
+	switch name {
+	case "", "auto":
+		return DetectTheme(noEmoji)
+	case ThemeEmoji.Name:
+		return ThemeEmoji
+	case ThemeUnicode.Name:
+		return ThemeUnicode
+	case ThemeASCII.Name:
+		return ThemeASCII
+	case ThemePlain.Name:
+		return ThemePlain
+	}
+	return nil
+}
+
+// DetectTheme picks a theme for stdout: ASCII when the locale is not UTF-8,
+// Unicode without emoji when stdout is not a terminal or emoji are disabled,
+// and emoji otherwise
+func DetectTheme(noEmoji bool) *Theme {
+	if !isUTF8Locale() {
+		return ThemeASCII
+	}
+	fd := os.Stdout.Fd()
+	if noEmoji || !(isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)) {
+		return ThemeUnicode
+	}
+	return ThemeEmoji
+}
+
+// isUTF8Locale reports whether the environment's locale uses UTF-8
+func isUTF8Locale() bool {
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			value = strings.ToLower(value)
+			return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+		}
+	}
+	return false
+}
+
+// Icon returns the theme's symbol for an icon ID, or "" when the theme has none
+func (t *Theme) Icon(id string) string {
+	return t.icons[id]
+}
+
+// Prefix returns the icon followed by a space, or "" when the theme has none
+func (t *Theme) Prefix(id string) string {
+	if icon := t.icons[id]; icon != "" {
+		return icon + " "
+	}
+	return ""
+}
+
+// PrayerIcon returns the icon for a prayer, including Jumu'ah slots and combined windows
+func (t *Theme) PrayerIcon(name string) string {
+	if first, _, ok := strings.Cut(name, " + "); ok {
+		name = first
+	}
+	if strings.HasPrefix(name, prayer.JumuahName) {
+		name = prayer.JumuahName
+	}
+	return t.icons[name]
+}
+
+// Rule returns a horizontal rule of the given width, or "" for plain output
+func (t *Theme) Rule(width int) string {
+	if t.Plain {
+		return ""
+	}
+	return strings.Repeat(t.Box.Rule, width)
+}
+
+// ProgressBar returns a bar of the given width filled to fraction (0 to 1),
+// or "" for plain output
+func (t *Theme) ProgressBar(fraction float64, width int) string {
+	if t.Plain {
+		return ""
+	}
+	filled := int(min(max(fraction, 0), 1)*float64(width) + 0.5)
+	return strings.Repeat(t.Box.BarFull, filled) + strings.Repeat(t.Box.BarEmpty, width-filled)
+}
+
+// Symbols returns the tablewriter symbols matching the theme's box drawing
+func (t *Theme) Symbols() tw.Symbols {
+	return tw.NewSymbols(t.Box.Style)
+}
+
+// line returns a box border of the given inner width, e.g., "┌────┐"
+func (t *Theme) line(left, right string, width int) string {
+	return left + strings.Repeat(t.Box.Horizontal, width) + right
+}
@@ -0,0 +1,143 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/AbdElrahmaN31/pray-cli/pkg/prayer"
+)
+
+// timetablePrayers are the columns of a timetable of several days
+var timetablePrayers = []string{"Fajr", "Sunrise", "Dhuhr", "Asr", "Maghrib", "Isha"}
+
+// timetable is a printable table of prayer times shared by the Markdown and HTML
+// formats: a day's prayers one per row, or several days one per row
+type timetable struct {
+	Title    string
+	Subtitle string // Date and Hijri date of a single day, or the first and last dates of a range
+	Header   []string
+	Rows     [][]timetableCell
+	Footer   []string // Mosque, Qibla, method and generated-at lines
+	RTL      bool
+	Lang     string
+}
+
+// timetableCell is a table cell with an optional second line, such as an Iqama time
+type timetableCell struct {
+	Text string
+	Sub  string
+}
+
+// buildTimetable lays out the days as a timetable, localized with the first day's settings
+func buildTimetable(days []*PrayerData) (*timetable, error) {
+	if len(days) == 0 || days[0] == nil {
+		return nil, errors.New("no prayer times data")
+	}
+	first := days[0]
+	l := first.localizer()
+	for _, data := range days {
+		if data == nil || data.Response == nil || data.Day == nil {
+			return nil, errors.New(l.T("error.noData"))
+		}
+	}
+
+	t := &timetable{
+		Title: l.T("heading.title", first.Location),
+		RTL:   l.IsRTL(),
+		Lang:  l.Lang(),
+	}
+	if len(days) == 1 {
+		t.dayRows(first, l)
+	} else {
+		t.rangeRows(days, l)
+	}
+
+	if first.Mosque != "" {
+		t.Footer = append(t.Footer, l.T("label.mosque", first.Mosque))
+	}
+	if len(days) == 1 && first.ShowQibla && first.Qibla != nil {
+		t.Footer = append(t.Footer, l.T("label.qibla", first.Qibla.Direction, CompassDirection(first.Qibla.Direction)))
+	}
+	if first.Method != "" {
+		t.Footer = append(t.Footer, l.T("label.method", first.Method))
+	}
+	now := first.now()
+	t.Footer = append(t.Footer, l.T("label.generated", l.DateOf(now)+" "+l.Time(now)))
+	return t, nil
+}
+
+// dayRows lists a single day's prayers with their Adhan and Iqama times
+func (t *timetable) dayRows(data *PrayerData, l *Localizer) {
+	day := data.Day
+	t.Subtitle = l.DateOf(day.Date)
+	if hijri := timetableHijri(data, l); hijri != "" {
+		t.Subtitle += " · " + hijri
+	}
+
+	t.Header = []string{l.T("column.prayer"), l.T("column.time")}
+	if data.HasIqama() {
+		t.Header = append(t.Header, l.T("column.iqama"))
+	}
+	for _, e := range day.Events {
+		row := []timetableCell{{Text: l.Prayer(e.Name)}, {Text: l.Time(e.Time)}}
+		if data.HasIqama() {
+			var iqama timetableCell
+			if e.HasIqama() {
+				iqama.Text = l.Time(e.Iqama)
+			}
+			row = append(row, iqama)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+}
+
+// rangeRows lists several days, one per row, with each prayer's Iqama time below its Adhan time
+func (t *timetable) rangeRows(days []*PrayerData, l *Localizer) {
+	first, last := days[0].Day, days[len(days)-1].Day
+	t.Subtitle = l.DateOf(first.Date) + " – " + l.DateOf(last.Date)
+
+	hasHijri := timetableHijri(days[0], l) != ""
+	t.Header = []string{l.T("column.date")}
+	if hasHijri {
+		t.Header = append(t.Header, l.T("column.hijri"))
+	}
+	for _, name := range timetablePrayers {
+		t.Header = append(t.Header, l.Prayer(name))
+	}
+
+	for _, data := range days {
+		day := data.Day
+		row := []timetableCell{{Text: l.DateOf(day.Date)}}
+		if hasHijri {
+			row = append(row, timetableCell{Text: timetableHijri(data, l)})
+		}
+		for _, name := range timetablePrayers {
+			row = append(row, timetableTimes(l, day, name))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+}
+
+// timetableHijri returns the day's localized Hijri date, or "" when it is hidden
+func timetableHijri(data *PrayerData, l *Localizer) string {
+	if !data.ShowHijri || data.HijriFormat == "none" {
+		return ""
+	}
+	return l.HijriDate(data.Day.Hijri)
+}
+
+// timetableTimes returns the localized Adhan and Iqama times of a prayer of the day.
+// On Fridays with Jumu'ah, Dhuhr is the first Jumu'ah.
+func timetableTimes(l *Localizer, day *prayer.Day, name string) timetableCell {
+	for _, e := range day.Events {
+		if e.Name == name || (name == "Dhuhr" && strings.HasPrefix(e.Name, prayer.JumuahName)) {
+			cell := timetableCell{Text: l.Time(e.Time)}
+			if e.HasIqama() {
+				cell.Sub = l.Time(e.Iqama)
+			}
+			return cell
+		}
+	}
+	return timetableCell{}
+}
@@ -0,0 +1,30 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// XMLFormatter formats output as XML with the structure of the JSON output, under a
+// pray root element. Lists repeat their element (e.g., one <times> per Jumu'ah).
+type XMLFormatter struct{}
+
+// Format writes the prayer times as XML, in an Envelope
+func (f *XMLFormatter) Format(w io.Writer, data *PrayerData) error {
+	output, err := buildJSONOutput(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(NewEnvelope(output)); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,26 @@
+// Package output provides output formatting for prayer times
+package output
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFormatter formats output as YAML with the structure of the JSON output
+type YAMLFormatter struct{}
+
+// Format writes the prayer times as YAML, in an Envelope
+func (f *YAMLFormatter) Format(w io.Writer, data *PrayerData) error {
+	output, err := buildJSONOutput(data)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(NewEnvelope(output)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
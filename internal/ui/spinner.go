@@ -4,15 +4,20 @@ package ui
 import (
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/fatih/color"
+
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
 )
 
-// Spinner provides a simple terminal spinner for long-running operations
+// Spinner provides a simple terminal spinner for long-running operations.
+// With the plain theme, the message is printed once instead of animated.
 type Spinner struct {
 	message  string
+	theme    *output.Theme
 	frames   []string
 	interval time.Duration
 	mu       sync.Mutex
@@ -20,11 +25,12 @@ type Spinner struct {
 	done     chan struct{}
 }
 
-// NewSpinner creates a new spinner with the given message
-func NewSpinner(message string) *Spinner {
+// NewSpinner creates a new spinner with the given message, drawn with the theme's characters
+func NewSpinner(message string, theme *output.Theme) *Spinner {
 	return &Spinner{
 		message:  message,
-		frames:   []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		theme:    theme,
+		frames:   strings.Split(theme.Box.Spinner, ""),
 		interval: 80 * time.Millisecond,
 		done:     make(chan struct{}),
 	}
@@ -40,6 +46,11 @@ func (s *Spinner) Start() {
 	s.running = true
 	s.mu.Unlock()
 
+	if s.theme.Plain {
+		fmt.Fprintln(os.Stdout, s.message)
+		return
+	}
+
 	go func() {
 		ticker := time.NewTicker(s.interval)
 		defer ticker.Stop()
@@ -75,7 +86,7 @@ func (s *Spinner) Stop() {
 
 	s.running = false
 	close(s.done)
-	fmt.Fprint(os.Stdout, "\r\033[K") // Clear line
+	fmt.Fprint(os.Stdout, s.clear())
 }
 
 // Success stops the spinner and shows a success message
@@ -91,7 +102,7 @@ func (s *Spinner) Success(message string) {
 	close(s.done)
 
 	green := color.New(color.FgGreen).SprintFunc()
-	fmt.Fprintf(os.Stdout, "\r\033[K%s %s\n", green("✓"), message)
+	fmt.Fprintf(os.Stdout, "%s%s%s\n", s.clear(), green(s.theme.Prefix("ok")), message)
 }
 
 // Fail stops the spinner and shows a failure message
@@ -107,7 +118,7 @@ func (s *Spinner) Fail(message string) {
 	close(s.done)
 
 	red := color.New(color.FgRed).SprintFunc()
-	fmt.Fprintf(os.Stdout, "\r\033[K%s %s\n", red("✗"), message)
+	fmt.Fprintf(os.Stdout, "%s%s%s\n", s.clear(), red(s.theme.Prefix("error")), message)
 }
 
 // Update updates the spinner message while running
@@ -116,3 +127,11 @@ func (s *Spinner) Update(message string) {
 	defer s.mu.Unlock()
 	s.message = message
 }
+
+// clear returns the escape codes that clear the spinner's line, or "" when nothing was drawn
+func (s *Spinner) clear() string {
+	if s.theme.Plain {
+		return ""
+	}
+	return "\r\033[K"
+}
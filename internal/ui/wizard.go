@@ -15,6 +15,7 @@ import (
 
 	"github.com/AbdElrahmaN31/pray-cli/internal/config"
 	"github.com/AbdElrahmaN31/pray-cli/internal/location"
+	"github.com/AbdElrahmaN31/pray-cli/internal/output"
 )
 
 // Wizard handles the interactive setup process
@@ -22,14 +23,16 @@ type Wizard struct {
 	reader io.Reader
 	writer io.Writer
 	cfg    *config.Config
+	theme  *output.Theme
 }
 
-// NewWizard creates a new setup wizard
-func NewWizard() *Wizard {
+// NewWizard creates a new setup wizard, decorated with the theme's icons and rules
+func NewWizard(theme *output.Theme) *Wizard {
 	return &Wizard{
 		reader: os.Stdin,
 		writer: os.Stdout,
 		cfg:    config.DefaultConfig(),
+		theme:  theme,
 	}
 }
 
@@ -40,13 +43,13 @@ func (w *Wizard) Run() (*config.Config, error) {
 	yellow := color.New(color.FgYellow).SprintFunc()
 
 	fmt.Fprintln(w.writer)
-	fmt.Fprintln(w.writer, "🕌 "+cyan("Prayer Times CLI - Initial Setup"))
-	fmt.Fprintln(w.writer, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(w.writer, w.theme.Prefix("mosque")+cyan("Prayer Times CLI - Initial Setup"))
+	w.rule()
 	fmt.Fprintln(w.writer)
 
 	// Step 1: Location Setup
 	fmt.Fprintln(w.writer, yellow("Step 1/5: Location Setup"))
-	fmt.Fprintln(w.writer, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	w.rule()
 	fmt.Fprintln(w.writer)
 	fmt.Fprintln(w.writer, "How would you like to set your location?")
 	fmt.Fprintln(w.writer)
@@ -61,7 +64,7 @@ func (w *Wizard) Run() (*config.Config, error) {
 	case "1":
 		// Auto-detect
 		fmt.Fprintln(w.writer)
-		fmt.Fprintln(w.writer, "🔍 Detecting your location...")
+		fmt.Fprintln(w.writer, w.theme.Prefix("search")+"Detecting your location...")
 
 		detector := location.NewDetector()
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
@@ -69,11 +72,11 @@ func (w *Wizard) Run() (*config.Config, error) {
 
 		loc, err := detector.DetectFromIP(ctx)
 		if err != nil {
-			fmt.Fprintf(w.writer, "❌ Failed to detect location: %v\n", err)
+			fmt.Fprintf(w.writer, "%sFailed to detect location: %v\n", w.theme.Prefix("error"), err)
 			fmt.Fprintln(w.writer, "Please enter your location manually:")
 			w.cfg.Location.Address = w.prompt("City or address")
 		} else {
-			fmt.Fprintf(w.writer, "%s Detected: %s\n", green("✓"), cyan(loc.GetDisplayAddress()))
+			fmt.Fprintf(w.writer, "%sDetected: %s\n", green(w.theme.Prefix("ok")), cyan(loc.GetDisplayAddress()))
 			fmt.Fprintf(w.writer, "  Coordinates: %.4f°N, %.4f°E\n", loc.Latitude, loc.Longitude)
 			fmt.Fprintf(w.writer, "  Timezone: %s\n", loc.Timezone)
 			fmt.Fprintln(w.writer)
@@ -115,7 +118,7 @@ func (w *Wizard) Run() (*config.Config, error) {
 
 	// Step 2: Calculation Method
 	fmt.Fprintln(w.writer, yellow("Step 2/5: Calculation Method"))
-	fmt.Fprintln(w.writer, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	w.rule()
 	fmt.Fprintln(w.writer)
 	fmt.Fprintln(w.writer, "Select your calculation method:")
 	fmt.Fprintln(w.writer)
@@ -139,7 +142,7 @@ func (w *Wizard) Run() (*config.Config, error) {
 
 	// Step 3: Language
 	fmt.Fprintln(w.writer, yellow("Step 3/5: Language"))
-	fmt.Fprintln(w.writer, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	w.rule()
 	fmt.Fprintln(w.writer)
 	fmt.Fprintln(w.writer, "Select your preferred language:")
 	fmt.Fprintln(w.writer)
@@ -158,7 +161,7 @@ func (w *Wizard) Run() (*config.Config, error) {
 
 	// Step 4: Display Features
 	fmt.Fprintln(w.writer, yellow("Step 4/5: Display Features"))
-	fmt.Fprintln(w.writer, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	w.rule()
 	fmt.Fprintln(w.writer)
 
 	w.cfg.Features.Qibla = w.confirm("Include Qibla direction?")
@@ -186,7 +189,7 @@ func (w *Wizard) Run() (*config.Config, error) {
 
 	// Step 5: Special Features
 	fmt.Fprintln(w.writer, yellow("Step 5/5: Special Features"))
-	fmt.Fprintln(w.writer, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	w.rule()
 	fmt.Fprintln(w.writer)
 
 	w.cfg.Jumuah.Enabled = w.confirm("Enable Jumu'ah (Friday prayer)?")
@@ -194,14 +197,21 @@ func (w *Wizard) Run() (*config.Config, error) {
 	w.cfg.Features.TravelerMode = w.confirm("Are you traveling (Qasr mode)?")
 
 	fmt.Fprintln(w.writer)
-	fmt.Fprintln(w.writer, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Fprintln(w.writer, green("✨ Setup Complete!"))
-	fmt.Fprintln(w.writer, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	w.rule()
+	fmt.Fprintln(w.writer, green(w.theme.Prefix("done")+"Setup Complete!"))
+	w.rule()
 	fmt.Fprintln(w.writer)
 
 	return w.cfg, nil
 }
 
+// rule prints a horizontal rule in the theme's characters, or nothing for plain output
+func (w *Wizard) rule() {
+	if rule := w.theme.Rule(45); rule != "" {
+		fmt.Fprintln(w.writer, rule)
+	}
+}
+
 // prompt asks for user input
 func (w *Wizard) prompt(question string) string {
 	fmt.Fprintf(w.writer, "%s: ", question)
@@ -0,0 +1,102 @@
+// Package prayer provides prayer times calculation helpers and data
+package prayer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Du'a categories
+const (
+	DuaMorning = "morning" // Morning adhkar, from Fajr until Dhuhr
+	DuaEvening = "evening" // Evening adhkar, from Asr until Isha
+	DuaDaily   = "daily"   // General daily du'a
+)
+
+// Dua is a single du'a or dhikr with its source reference
+type Dua struct {
+	ID              string `json:"id" yaml:"id" xml:"id"`
+	Category        string `json:"category" yaml:"category" xml:"category"`
+	Arabic          string `json:"arabic" yaml:"arabic" xml:"arabic"`
+	Transliteration string `json:"transliteration" yaml:"transliteration" xml:"transliteration"`
+	Translation     string `json:"translation" yaml:"translation" xml:"translation"`
+	Source          string `json:"source" yaml:"source" xml:"source"`
+	Repeat          int    `json:"repeat,omitempty" yaml:"repeat,omitempty" xml:"repeat,omitempty"` // Recommended repetitions (0 = once)
+}
+
+//go:embed adhkar.json
+var adhkarData []byte
+
+var (
+	adhkarOnce sync.Once
+	adhkar     []Dua
+)
+
+// Adhkar returns the embedded du'a and adhkar dataset
+func Adhkar() []Dua {
+	adhkarOnce.Do(func() {
+		// The dataset is embedded and covered by tests, so a parse error leaves it empty
+		_ = json.Unmarshal(adhkarData, &adhkar)
+	})
+	return adhkar
+}
+
+// AdhkarByCategory returns the entries in a category
+func AdhkarByCategory(category string) []Dua {
+	var result []Dua
+	for _, d := range Adhkar() {
+		if d.Category == category {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// DuaCategoryFor returns the du'a category that fits the time before the given upcoming prayer
+func DuaCategoryFor(nextPrayer string) string {
+	if strings.HasPrefix(nextPrayer, JumuahName) {
+		nextPrayer = "Dhuhr"
+	}
+	switch nextPrayer {
+	case "Sunrise", "Dhuhr":
+		return DuaMorning
+	case "Maghrib", "Isha":
+		return DuaEvening
+	}
+	return DuaDaily
+}
+
+// SelectDua deterministically picks a du'a for the date and upcoming prayer,
+// so every run on the same day and prayer window shows the same entry.
+// It returns nil when the dataset is empty.
+func SelectDua(date time.Time, nextPrayer string) *Dua {
+	entries := AdhkarByCategory(DuaCategoryFor(nextPrayer))
+	if len(entries) == 0 {
+		entries = Adhkar()
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// Days since the Unix epoch for the calendar date, independent of the time zone
+	y, m, d := date.Date()
+	day := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+
+	n := len(entries)
+	dua := entries[(day%n+n)%n]
+	return &dua
+}
+
+// Title returns a display heading for the du'a category
+func (d *Dua) Title() string {
+	switch d.Category {
+	case DuaMorning:
+		return "Morning Adhkar"
+	case DuaEvening:
+		return "Evening Adhkar"
+	}
+	return "Today's Du'a"
+}
@@ -0,0 +1,69 @@
+package prayer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdhkarDataset(t *testing.T) {
+	entries := Adhkar()
+	if len(entries) == 0 {
+		t.Fatal("Adhkar() returned no entries")
+	}
+
+	ids := make(map[string]bool)
+	for _, d := range entries {
+		if d.ID == "" || d.Arabic == "" || d.Transliteration == "" || d.Translation == "" || d.Source == "" {
+			t.Errorf("incomplete entry: %+v", d)
+		}
+		if ids[d.ID] {
+			t.Errorf("duplicate id: %s", d.ID)
+		}
+		ids[d.ID] = true
+	}
+
+	for _, category := range []string{DuaMorning, DuaEvening, DuaDaily} {
+		if len(AdhkarByCategory(category)) == 0 {
+			t.Errorf("no entries in category %s", category)
+		}
+	}
+}
+
+func TestSelectDua(t *testing.T) {
+	date := time.Date(2026, 2, 4, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		next string
+		want string
+	}{
+		{"Dhuhr", DuaMorning},
+		{"Maghrib", DuaEvening},
+		{"Asr", DuaDaily},
+		{"", DuaDaily},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.next, func(t *testing.T) {
+			dua := SelectDua(date, tt.next)
+			if dua == nil {
+				t.Fatal("SelectDua() returned nil")
+			}
+			if dua.Category != tt.want {
+				t.Errorf("SelectDua() category = %s, want %s", dua.Category, tt.want)
+			}
+		})
+	}
+
+	// Deterministic within a day, regardless of time
+	a := SelectDua(date, "Asr")
+	b := SelectDua(date.Add(10*time.Hour), "Asr")
+	if a.ID != b.ID {
+		t.Errorf("SelectDua() not deterministic: %s != %s", a.ID, b.ID)
+	}
+
+	// Rotates across days
+	c := SelectDua(date.AddDate(0, 0, 1), "Asr")
+	if a.ID == c.ID {
+		t.Error("SelectDua() should rotate entries across days")
+	}
+}
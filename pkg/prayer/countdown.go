@@ -0,0 +1,57 @@
+// Package prayer provides prayer times calculation helpers and data
+package prayer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseThresholds parses comma-separated T-minus alert thresholds, as durations
+// (e.g., "10m,1m30s") or whole minutes (e.g., "10,5,0"), sorted from the largest
+func ParseThresholds(s string) ([]time.Duration, error) {
+	var thresholds []time.Duration
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			minutes, convErr := strconv.Atoi(part)
+			if convErr != nil {
+				return nil, fmt.Errorf("invalid alert threshold %q: use minutes or a duration like 10m", part)
+			}
+			d = time.Duration(minutes) * time.Minute
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid alert threshold %q: must not be negative", part)
+		}
+		thresholds = append(thresholds, d)
+	}
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] > thresholds[j] })
+	return thresholds, nil
+}
+
+// Crossed returns the smallest threshold passed between two readings of the time
+// remaining until an event. Thresholds must be sorted from the largest. Readings may be
+// far apart (e.g., after a suspend), in which case only the latest threshold counts.
+func Crossed(thresholds []time.Duration, before, after time.Duration) (time.Duration, bool) {
+	for i := len(thresholds) - 1; i >= 0; i-- {
+		if before > thresholds[i] && after <= thresholds[i] {
+			return thresholds[i], true
+		}
+	}
+	return 0, false
+}
+
+// Progress returns how far now is between start and end, from 0 to 1
+func Progress(start, end, now time.Time) float64 {
+	total := end.Sub(start)
+	if total <= 0 {
+		return 1
+	}
+	return min(max(float64(now.Sub(start))/float64(total), 0), 1)
+}
@@ -0,0 +1,80 @@
+package prayer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseThresholds(t *testing.T) {
+	got, err := ParseThresholds("5m, 10, 0,1m30s")
+	if err != nil {
+		t.Fatalf("ParseThresholds() error = %v", err)
+	}
+	want := []time.Duration{10 * time.Minute, 5 * time.Minute, 90 * time.Second, 0}
+	if len(got) != len(want) {
+		t.Fatalf("ParseThresholds() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ParseThresholds() = %v, want %v", got, want)
+		}
+	}
+
+	if got, err := ParseThresholds(""); err != nil || len(got) != 0 {
+		t.Errorf("ParseThresholds(\"\") = %v, %v; want none", got, err)
+	}
+	for _, s := range []string{"soon", "-5m", "10,x"} {
+		if _, err := ParseThresholds(s); err == nil {
+			t.Errorf("ParseThresholds(%q) should fail", s)
+		}
+	}
+}
+
+func TestCrossed(t *testing.T) {
+	thresholds := []time.Duration{10 * time.Minute, 5 * time.Minute, 0}
+
+	tests := []struct {
+		before, after time.Duration
+		want          time.Duration
+		ok            bool
+	}{
+		{11 * time.Minute, 10*time.Minute + 59*time.Second, 0, false},
+		{10*time.Minute + time.Second, 10 * time.Minute, 10 * time.Minute, true},
+		{time.Second, 0, 0, true},
+		{time.Second, -time.Second, 0, true},
+		{0, -time.Second, 0, false},
+		// After a suspend, only the latest threshold fires
+		{time.Hour, 2 * time.Minute, 5 * time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := Crossed(thresholds, tt.before, tt.after)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Crossed(%v, %v) = %v, %v; want %v, %v", tt.before, tt.after, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	start := time.Date(2026, 1, 9, 12, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	tests := []struct {
+		now  time.Time
+		want float64
+	}{
+		{start, 0},
+		{start.Add(time.Hour), 0.25},
+		{end, 1},
+		{start.Add(-time.Hour), 0},
+		{end.Add(time.Hour), 1},
+	}
+	for _, tt := range tests {
+		if got := Progress(start, end, tt.now); got != tt.want {
+			t.Errorf("Progress(%s) = %v, want %v", tt.now.Format("15:04"), got, tt.want)
+		}
+	}
+	if got := Progress(end, start, start); got != 1 {
+		t.Errorf("Progress() of an empty window = %v, want 1", got)
+	}
+}
@@ -0,0 +1,336 @@
+// Package prayer provides prayer times calculation helpers and data
+package prayer
+
+import (
+	"strings"
+	"time"
+)
+
+// EventNames lists the events of a prayer day in chronological order
+var EventNames = []string{"Fajr", "Sunrise", "Dhuhr", "Asr", "Maghrib", "Isha", "Midnight"}
+
+// Event is a named time in a prayer day: a prayer, Sunrise, Midnight or a Jumu'ah khutbah
+type Event struct {
+	Name  string    // e.g., "Fajr", "Jumu'ah 2" or, once combined, "Dhuhr + Asr"
+	Time  time.Time // Adhan time in the day's timezone
+	Iqama time.Time // Iqama time, zero when the event has none
+}
+
+// Clock returns the event's time of day (HH:MM)
+func (e Event) Clock() string {
+	return e.Time.Format("15:04")
+}
+
+// HasIqama reports whether the event has an iqama time
+func (e Event) HasIqama() bool {
+	return !e.Iqama.IsZero()
+}
+
+// HijriDate is a date in the Hijri (Islamic) calendar
+type HijriDate struct {
+	Day     string
+	Month   int
+	MonthEn string
+	MonthAr string
+	Year    string
+}
+
+// Day is a normalized prayer day: typed event times in the location's timezone,
+// Iqama and Jumu'ah times, traveler windows, the Hijri date, location and method.
+// It is built once from an API response and shared by all outputs.
+type Day struct {
+	Date      time.Time // Start of the day in the location's timezone
+	Location  string
+	Latitude  float64
+	Longitude float64
+	Method    string
+	Hijri     HijriDate
+	Events    []Event // In chronological order
+	Windows   []CombinedWindow
+	Traveler  bool
+	Following *Day // The next day; estimated from this day's times when nil
+}
+
+// NewDay builds a day from adhan times (HH:MM) keyed by event name. Times are placed
+// on date in date's location, so DST transitions are handled by the time package.
+// An event earlier than the one before it (e.g., Midnight at 00:09) belongs to the next day.
+// Events with missing or invalid times are omitted.
+func NewDay(date time.Time, times map[string]string) *Day {
+	day := &Day{
+		Date: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()),
+	}
+
+	clocks := make(map[string]string)
+	offset, previous := 0, -1
+	for _, name := range EventNames {
+		minutes, err := ParseClock(times[name])
+		if err != nil {
+			continue
+		}
+		if minutes < previous {
+			offset++
+		}
+		previous = minutes
+		clocks[name] = FormatClock(minutes)
+		day.Events = append(day.Events, Event{Name: name, Time: day.at(offset, minutes)})
+	}
+	day.Windows = CombinedWindows(clocks)
+
+	return day
+}
+
+// at returns the time of day on the day's date plus the given number of days
+func (d *Day) at(days, minutes int) time.Time {
+	return time.Date(d.Date.Year(), d.Date.Month(), d.Date.Day()+days, minutes/60, minutes%60, 0, 0, d.Date.Location())
+}
+
+// onDayOf returns the clock time (HH:MM) on the same date as t, or the following date
+// when it is earlier than t
+func (d *Day) onDayOf(t time.Time, clock string) (time.Time, bool) {
+	minutes, err := ParseClock(clock)
+	if err != nil {
+		return time.Time{}, false
+	}
+	at := time.Date(t.Year(), t.Month(), t.Day(), minutes/60, minutes%60, 0, 0, d.Date.Location())
+	if at.Before(t) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, true
+}
+
+// SetIqama sets the iqama times (HH:MM) keyed by prayer name
+func (d *Day) SetIqama(times map[string]string) {
+	for i := range d.Events {
+		if at, ok := d.onDayOf(d.Events[i].Time, times[d.Events[i].Name]); ok {
+			d.Events[i].Iqama = at
+		}
+	}
+}
+
+// SetJumuah replaces Dhuhr with one event per Jumu'ah khutbah time (HH:MM)
+func (d *Day) SetJumuah(times []string) {
+	if len(times) == 0 {
+		return
+	}
+
+	events := make([]Event, 0, len(d.Events)+len(times)-1)
+	for _, e := range d.Events {
+		if e.Name != "Dhuhr" {
+			events = append(events, e)
+			continue
+		}
+		for i, t := range times {
+			if minutes, err := ParseClock(t); err == nil {
+				events = append(events, Event{Name: JumuahSlotName(i), Time: d.at(0, minutes)})
+			}
+		}
+	}
+	d.Events = events
+}
+
+// HasIqama reports whether any of the day's events has an iqama time
+func (d *Day) HasIqama() bool {
+	for _, e := range d.Events {
+		if e.HasIqama() {
+			return true
+		}
+	}
+	return false
+}
+
+// Jumuah returns the Jumu'ah events that replace Dhuhr on a Friday, or nil on other days
+func (d *Day) Jumuah() []Event {
+	var events []Event
+	for _, e := range d.Events {
+		if strings.HasPrefix(e.Name, JumuahName) {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Event returns the event with the given name, or nil when the day has none
+func (d *Day) Event(name string) *Event {
+	for i := range d.Events {
+		if d.Events[i].Name == name {
+			return &d.Events[i]
+		}
+	}
+	return nil
+}
+
+// Next returns the first event after now, rolling over to the following day's events
+// once all of this day's have passed, or nil when those have passed too.
+// Midnight only ends Isha's time and is never next.
+func (d *Day) Next(now time.Time) *Event {
+	if e := NextEvent(d.Events, now); e != nil {
+		return e
+	}
+	return NextEvent(d.Tomorrow().Events, now)
+}
+
+// NextPrayer returns the next prayer to announce, like Next except that traveler days
+// combine prayers into a single event at the time of the first prayer (e.g., "Dhuhr + Asr"),
+// as Upcoming lists them
+func (d *Day) NextPrayer(now time.Time) *Event {
+	if e := NextEvent(d.prayers(), now); e != nil {
+		return e
+	}
+	return NextEvent(d.Tomorrow().prayers(), now)
+}
+
+// prayers returns the day's events, combined on traveler days
+func (d *Day) prayers() []Event {
+	if d.Traveler {
+		return d.Combined()
+	}
+	return d.Events
+}
+
+// Upcoming returns the next n events after now, continuing into the following days.
+// Traveler days list their combined prayers. Midnight is skipped.
+func (d *Day) Upcoming(now time.Time, n int) []Event {
+	var events []Event
+	for day := d; len(events) < n && len(day.Events) > 0; day = day.Tomorrow() {
+		for _, e := range day.prayers() {
+			if len(events) == n {
+				break
+			}
+			if e.Name != "Midnight" && e.Time.After(now) {
+				events = append(events, e)
+			}
+		}
+	}
+	return events
+}
+
+// IsLaterDate reports whether t falls on a later date than the day
+func (d *Day) IsLaterDate(t time.Time) bool {
+	return !t.Before(d.Date.AddDate(0, 0, 1))
+}
+
+// Tomorrow returns the following day. When its times have not been set, they are estimated
+// by placing this day's adhan times on the next date, which is within a few minutes.
+func (d *Day) Tomorrow() *Day {
+	if d.Following != nil {
+		return d.Following
+	}
+
+	times := make(map[string]string)
+	for _, e := range d.Events {
+		times[e.Name] = e.Clock()
+	}
+	// Jumu'ah replaces Dhuhr only on Fridays, but the Dhuhr + Asr window keeps its time
+	for _, w := range d.Windows {
+		if _, ok := times[w.First]; !ok {
+			times[w.First] = w.Taqdim
+		}
+	}
+
+	tomorrow := NewDay(d.Date.AddDate(0, 0, 1), times)
+	tomorrow.Location = d.Location
+	tomorrow.Latitude = d.Latitude
+	tomorrow.Longitude = d.Longitude
+	tomorrow.Method = d.Method
+	tomorrow.Traveler = d.Traveler
+	return tomorrow
+}
+
+// Current returns the last event at or before now, or nil before the first event.
+// Midnight is skipped, so after Isha the current event stays Isha.
+func (d *Day) Current(now time.Time) *Event {
+	var current *Event
+	for i := range d.Events {
+		if d.Events[i].Name == "Midnight" {
+			continue
+		}
+		if d.Events[i].Time.After(now) {
+			break
+		}
+		current = &d.Events[i]
+	}
+	return current
+}
+
+// Window returns the events around now: the current one and the next. Before the day's
+// first event, the window starts at the previous day's Isha, estimated from this day's.
+// Either is nil when the day has no such event.
+func (d *Day) Window(now time.Time) (start, end *Event) {
+	start, end = d.Current(now), d.Next(now)
+	if start == nil {
+		if isha := d.Event("Isha"); isha != nil {
+			previous := *isha
+			previous.Time = previous.Time.AddDate(0, 0, -1)
+			previous.Iqama = time.Time{}
+			start = &previous
+		}
+	}
+	return start, end
+}
+
+// NextEvent returns the first event in events after now, skipping Midnight, or nil when all have passed
+func NextEvent(events []Event, now time.Time) *Event {
+	for i := range events {
+		if events[i].Name != "Midnight" && events[i].Time.After(now) {
+			return &events[i]
+		}
+	}
+	return nil
+}
+
+// Combined returns the events with the prayers a traveler may combine merged into one
+// event at the time of the first prayer. A pair is only merged when its first prayer is
+// present (e.g., not when Jumu'ah replaces Dhuhr).
+func (d *Day) Combined() []Event {
+	byFirst := make(map[string]CombinedWindow)
+	skip := make(map[string]bool)
+	for _, w := range d.Windows {
+		if d.Event(w.First) != nil {
+			byFirst[w.First] = w
+			skip[w.Second] = true
+		}
+	}
+
+	events := make([]Event, 0, len(d.Events))
+	for _, e := range d.Events {
+		if skip[e.Name] {
+			continue
+		}
+		if w, ok := byFirst[e.Name]; ok {
+			e.Name = w.Name
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// ActiveWindow returns the combined window open at now and the time it ends
+func (d *Day) ActiveWindow(now time.Time) (CombinedWindow, time.Time, bool) {
+	for _, w := range d.Windows {
+		// The first prayer's event may be replaced by Jumu'ah, so use the window's own times.
+		// The end rolls to the next day when earlier than the start (e.g., Midnight at 00:09).
+		start, ok := d.onDayOf(d.Date, w.Taqdim)
+		if !ok {
+			continue
+		}
+		end, ok := d.onDayOf(start, w.End)
+		if !ok {
+			continue
+		}
+		if !now.Before(start) && now.Before(end) {
+			return w, end, true
+		}
+	}
+	return CombinedWindow{}, time.Time{}, false
+}
+
+// PendingIqama returns the prayer whose adhan has passed but whose iqama is still upcoming
+func (d *Day) PendingIqama(now time.Time) *Event {
+	for i := range d.Events {
+		e := &d.Events[i]
+		if e.HasIqama() && !now.Before(e.Time) && now.Before(e.Iqama) {
+			return e
+		}
+	}
+	return nil
+}
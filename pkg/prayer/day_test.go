@@ -0,0 +1,309 @@
+package prayer
+
+import (
+	"testing"
+	"time"
+)
+
+var testTimes = map[string]string{
+	"Fajr":     "05:15",
+	"Sunrise":  "06:40",
+	"Dhuhr":    "12:09",
+	"Asr":      "15:12",
+	"Maghrib":  "17:34",
+	"Isha":     "18:54",
+	"Midnight": "00:09",
+}
+
+func testDay(t *testing.T) *Day {
+	t.Helper()
+	loc := time.FixedZone("EET", 2*60*60)
+	return NewDay(time.Date(2026, 1, 9, 15, 0, 0, 0, loc), testTimes)
+}
+
+func TestNewDay(t *testing.T) {
+	day := testDay(t)
+
+	if len(day.Events) != len(EventNames) {
+		t.Fatalf("NewDay() has %d events, want %d", len(day.Events), len(EventNames))
+	}
+	if !day.Date.Equal(time.Date(2026, 1, 9, 0, 0, 0, 0, day.Date.Location())) {
+		t.Errorf("Date = %v, want start of day", day.Date)
+	}
+
+	fajr := day.Event("Fajr")
+	if fajr == nil || fajr.Clock() != "05:15" || fajr.Time.Day() != 9 {
+		t.Errorf("unexpected Fajr: %+v", fajr)
+	}
+
+	// Midnight after midnight belongs to the next day
+	midnight := day.Event("Midnight")
+	if midnight == nil || midnight.Time.Day() != 10 || midnight.Clock() != "00:09" {
+		t.Errorf("unexpected Midnight: %+v", midnight)
+	}
+
+	if len(day.Windows) != 2 {
+		t.Errorf("NewDay() has %d windows, want 2", len(day.Windows))
+	}
+
+	// Invalid times are omitted
+	if got := NewDay(day.Date, map[string]string{"Fajr": "05:15", "Dhuhr": "bad"}); len(got.Events) != 1 {
+		t.Errorf("NewDay() with invalid time has %d events, want 1", len(got.Events))
+	}
+}
+
+func TestNewDayDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone data unavailable")
+	}
+
+	// Clocks spring forward at 02:00 on 8 March 2026
+	day := NewDay(time.Date(2026, 3, 8, 12, 0, 0, 0, loc), testTimes)
+	fajr := day.Event("Fajr")
+	if fajr.Clock() != "05:15" {
+		t.Errorf("Fajr clock = %s, want 05:15", fajr.Clock())
+	}
+	if got := fajr.Time.Sub(day.Date); got != 4*time.Hour+15*time.Minute {
+		t.Errorf("Fajr is %v after the start of the day, want 4h15m", got)
+	}
+}
+
+func TestDayNextAndCurrent(t *testing.T) {
+	day := testDay(t)
+	at := func(h, m int) time.Time {
+		return time.Date(2026, 1, 9, h, m, 0, 0, day.Date.Location())
+	}
+
+	tests := []struct {
+		now     time.Time
+		next    string
+		current string
+	}{
+		{at(4, 0), "Fajr", ""},
+		{at(5, 15), "Sunrise", "Fajr"},
+		{at(13, 0), "Asr", "Dhuhr"},
+		// After Isha the next prayer is tomorrow's Fajr
+		{at(19, 0), "Fajr", "Isha"},
+		{at(23, 59).Add(20 * time.Minute), "Fajr", "Isha"},
+	}
+
+	for _, tt := range tests {
+		next, current := "", ""
+		if e := day.Next(tt.now); e != nil {
+			next = e.Name
+		}
+		if e := day.Current(tt.now); e != nil {
+			current = e.Name
+		}
+		if next != tt.next || current != tt.current {
+			t.Errorf("at %s: Next() = %q, Current() = %q, want %q, %q",
+				tt.now.Format("15:04"), next, current, tt.next, tt.current)
+		}
+	}
+}
+
+func TestDayNextPrayer(t *testing.T) {
+	day := testDay(t)
+	at := func(h, m int) time.Time {
+		return time.Date(2026, 1, 9, h, m, 0, 0, day.Date.Location())
+	}
+
+	if e := day.NextPrayer(at(13, 0)); e == nil || e.Name != "Asr" {
+		t.Errorf("NextPrayer(13:00) = %+v, want Asr", e)
+	}
+
+	// Traveler days combine prayers, so after Dhuhr + Asr the next prayer is Maghrib + Isha
+	day.Traveler = true
+	tests := []struct {
+		now  time.Time
+		next string
+	}{
+		{at(11, 0), "Dhuhr + Asr"},
+		{at(13, 0), "Maghrib + Isha"},
+		{at(18, 0), "Fajr"},
+	}
+	for _, tt := range tests {
+		if e := day.NextPrayer(tt.now); e == nil || e.Name != tt.next {
+			t.Errorf("traveler NextPrayer(%s) = %+v, want %s", tt.now.Format("15:04"), e, tt.next)
+		}
+	}
+}
+
+func TestDaySetIqama(t *testing.T) {
+	day := testDay(t)
+	if day.HasIqama() {
+		t.Error("HasIqama() = true before SetIqama")
+	}
+	day.SetIqama(map[string]string{"Fajr": "05:35", "Isha": "00:05"})
+	if !day.HasIqama() {
+		t.Error("HasIqama() = false after SetIqama")
+	}
+
+	fajr := day.Event("Fajr")
+	if !fajr.HasIqama() || fajr.Iqama.Format("15:04") != "05:35" {
+		t.Errorf("unexpected Fajr iqama: %v", fajr.Iqama)
+	}
+	// An iqama earlier than its adhan is on the next day
+	if isha := day.Event("Isha"); isha.Iqama.Day() != 10 {
+		t.Errorf("Isha iqama day = %d, want 10", isha.Iqama.Day())
+	}
+	if day.Event("Dhuhr").HasIqama() {
+		t.Error("Dhuhr should have no iqama")
+	}
+
+	now := time.Date(2026, 1, 9, 5, 20, 0, 0, day.Date.Location())
+	if pending := day.PendingIqama(now); pending == nil || pending.Name != "Fajr" {
+		t.Errorf("PendingIqama() = %+v, want Fajr", pending)
+	}
+	if pending := day.PendingIqama(now.Add(time.Hour)); pending != nil {
+		t.Errorf("PendingIqama() = %+v, want nil", pending)
+	}
+}
+
+func TestDaySetJumuah(t *testing.T) {
+	day := testDay(t)
+	if jumuah := day.Jumuah(); jumuah != nil {
+		t.Errorf("Jumuah() = %v before SetJumuah, want nil", jumuah)
+	}
+	day.SetJumuah([]string{"12:30", "13:30"})
+	if jumuah := day.Jumuah(); len(jumuah) != 2 || jumuah[0].Clock() != "12:30" || jumuah[1].Clock() != "13:30" {
+		t.Errorf("Jumuah() = %v, want 12:30 and 13:30", jumuah)
+	}
+
+	var names []string
+	for _, e := range day.Events {
+		names = append(names, e.Name)
+	}
+	want := []string{"Fajr", "Sunrise", "Jumu'ah", "Jumu'ah 2", "Asr", "Maghrib", "Isha", "Midnight"}
+	if len(names) != len(want) {
+		t.Fatalf("events = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("events = %v, want %v", names, want)
+		}
+	}
+
+	// Dhuhr + Asr is not combined when Jumu'ah replaces Dhuhr
+	for _, e := range day.Combined() {
+		if e.Name == "Dhuhr + Asr" {
+			t.Error("Combined() merged Dhuhr + Asr on Friday")
+		}
+	}
+}
+
+func TestDayCombinedAndActiveWindow(t *testing.T) {
+	day := testDay(t)
+
+	var names []string
+	for _, e := range day.Combined() {
+		names = append(names, e.Name)
+	}
+	want := []string{"Fajr", "Sunrise", "Dhuhr + Asr", "Maghrib + Isha", "Midnight"}
+	if len(names) != len(want) {
+		t.Fatalf("Combined() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("Combined() = %v, want %v", names, want)
+		}
+	}
+
+	now := time.Date(2026, 1, 9, 23, 0, 0, 0, day.Date.Location())
+	w, end, ok := day.ActiveWindow(now)
+	if !ok || w.Name != "Maghrib + Isha" || !end.Equal(day.Event("Midnight").Time) {
+		t.Errorf("ActiveWindow() = %v, %v, %v, want Maghrib + Isha until Midnight", w.Name, end, ok)
+	}
+
+	if _, _, ok := day.ActiveWindow(now.Add(-20 * time.Hour)); ok {
+		t.Error("ActiveWindow() before Dhuhr should be closed")
+	}
+}
+
+func TestDayTomorrow(t *testing.T) {
+	day := testDay(t)
+	now := time.Date(2026, 1, 9, 20, 0, 0, 0, day.Date.Location())
+
+	// Without the following day's times, tomorrow is estimated from today's
+	next := day.Next(now)
+	if next == nil || next.Name != "Fajr" || next.Time.Day() != 10 || next.Clock() != "05:15" {
+		t.Fatalf("Next() after Isha = %+v, want Fajr on the 10th at 05:15", next)
+	}
+	if !day.IsLaterDate(next.Time) {
+		t.Error("IsLaterDate() = false for tomorrow's Fajr")
+	}
+	if day.IsLaterDate(day.Event("Isha").Time) {
+		t.Error("IsLaterDate() = true for today's Isha")
+	}
+
+	// Jumu'ah keeps tomorrow's Dhuhr
+	day.SetJumuah([]string{"12:30"})
+	if dhuhr := day.Tomorrow().Event("Dhuhr"); dhuhr == nil || dhuhr.Clock() != "12:09" {
+		t.Errorf("Tomorrow() Dhuhr = %+v, want 12:09", dhuhr)
+	}
+
+	// Fetched times replace the estimate
+	day.Following = NewDay(day.Date.AddDate(0, 0, 1), map[string]string{"Fajr": "05:16"})
+	if next := day.Next(now); next == nil || next.Clock() != "05:16" {
+		t.Errorf("Next() with the following day = %+v, want 05:16", next)
+	}
+
+	// Nothing is next once tomorrow's prayers have passed too
+	if next := day.Next(now.AddDate(0, 0, 2)); next != nil {
+		t.Errorf("Next() two days later = %+v, want nil", next)
+	}
+}
+
+func TestDayUpcoming(t *testing.T) {
+	day := testDay(t)
+	now := time.Date(2026, 1, 9, 17, 0, 0, 0, day.Date.Location())
+
+	var got []string
+	for _, e := range day.Upcoming(now, 5) {
+		got = append(got, e.Name+" "+e.Time.Format("02 15:04"))
+	}
+	want := []string{"Maghrib 09 17:34", "Isha 09 18:54", "Fajr 10 05:15", "Sunrise 10 06:40", "Dhuhr 10 12:09"}
+	if len(got) != len(want) {
+		t.Fatalf("Upcoming() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Upcoming() = %v, want %v", got, want)
+		}
+	}
+
+	// Traveler days list combined prayers
+	day.Traveler = true
+	if events := day.Upcoming(now, 2); len(events) != 2 || events[0].Name != "Maghrib + Isha" || events[1].Name != "Fajr" {
+		t.Errorf("traveler Upcoming() = %+v, want Maghrib + Isha and Fajr", events)
+	}
+
+	if events := (&Day{}).Upcoming(now, 3); len(events) != 0 {
+		t.Errorf("Upcoming() of an empty day = %+v, want none", events)
+	}
+}
+
+func TestDayWindow(t *testing.T) {
+	day := testDay(t)
+	at := func(h, m int) time.Time {
+		return time.Date(2026, 1, 9, h, m, 0, 0, day.Date.Location())
+	}
+
+	start, end := day.Window(at(13, 0))
+	if start == nil || end == nil || start.Name != "Dhuhr" || end.Name != "Asr" {
+		t.Errorf("Window(13:00) = %+v, %+v; want Dhuhr to Asr", start, end)
+	}
+
+	// Before Fajr, the window starts at the previous day's Isha
+	start, end = day.Window(at(3, 0))
+	if start == nil || start.Name != "Isha" || start.Time.Day() != 8 || end.Name != "Fajr" {
+		t.Errorf("Window(03:00) = %+v, %+v; want Isha on the 8th to Fajr", start, end)
+	}
+
+	// After Isha, the window ends at tomorrow's Fajr
+	start, end = day.Window(at(22, 0))
+	if start.Name != "Isha" || end.Name != "Fajr" || end.Time.Day() != 10 {
+		t.Errorf("Window(22:00) = %+v, %+v; want Isha to Fajr on the 10th", start, end)
+	}
+}
@@ -0,0 +1,61 @@
+// Package prayer provides prayer times calculation helpers and data
+package prayer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JumuahName is the display name of the Friday congregational prayer
+const JumuahName = "Jumu'ah"
+
+// ParseJumuahTimes parses comma-separated khutbah times (e.g., "13:00,14:15")
+func ParseJumuahTimes(s string) ([]string, error) {
+	var times []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		minutes, err := ParseClock(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Jumu'ah time: %w", err)
+		}
+		times = append(times, FormatClock(minutes))
+	}
+	return times, nil
+}
+
+// JumuahSlotName returns the display name for the n-th (0-based) Jumu'ah khutbah
+func JumuahSlotName(n int) string {
+	if n == 0 {
+		return JumuahName
+	}
+	return fmt.Sprintf("%s %d", JumuahName, n+1)
+}
+
+// FridayReminders returns the Friday reminders that apply at the given moment.
+// maghrib is the Maghrib time and jumuah the first khutbah time on the same day as now.
+//
+// The Surah al-Kahf window runs from Thursday Maghrib until Friday Maghrib.
+func FridayReminders(now, maghrib, jumuah time.Time) []string {
+	var reminders []string
+
+	switch now.Weekday() {
+	case time.Thursday:
+		if !now.Before(maghrib) {
+			reminders = append(reminders, "Friday eve: read Surah al-Kahf by Friday Maghrib")
+		}
+	case time.Friday:
+		if jumuah.IsZero() || now.Before(jumuah) {
+			reminders = append(reminders, "Perform ghusl and go early to Jumu'ah")
+		}
+		if now.Before(maghrib) {
+			reminders = append(reminders, fmt.Sprintf("Read Surah al-Kahf before Maghrib (%s)", maghrib.Format("15:04")))
+		}
+		reminders = append(reminders, "Send abundant salawat upon the Prophet ﷺ")
+	}
+
+	return reminders
+}
@@ -0,0 +1,72 @@
+package prayer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseJumuahTimes(t *testing.T) {
+	times, err := ParseJumuahTimes("13:00, 14:15,")
+	if err != nil {
+		t.Fatalf("ParseJumuahTimes() error = %v", err)
+	}
+	if len(times) != 2 || times[0] != "13:00" || times[1] != "14:15" {
+		t.Errorf("ParseJumuahTimes() = %v, want [13:00 14:15]", times)
+	}
+
+	if times, _ := ParseJumuahTimes(""); len(times) != 0 {
+		t.Errorf("ParseJumuahTimes(\"\") = %v, want empty", times)
+	}
+
+	if _, err := ParseJumuahTimes("13:00,25:00"); err == nil {
+		t.Error("ParseJumuahTimes() expected error for invalid time")
+	}
+}
+
+func TestJumuahSlotName(t *testing.T) {
+	if got := JumuahSlotName(0); got != "Jumu'ah" {
+		t.Errorf("JumuahSlotName(0) = %s, want Jumu'ah", got)
+	}
+	if got := JumuahSlotName(1); got != "Jumu'ah 2" {
+		t.Errorf("JumuahSlotName(1) = %s, want Jumu'ah 2", got)
+	}
+}
+
+func TestFridayReminders(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		// 2026-02-05 is a Thursday
+		return time.Date(2026, 2, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		jumuah   time.Time
+		contains []string
+		count    int
+	}{
+		{"thursday afternoon", at(5, 15, 0), time.Time{}, nil, 0},
+		{"thursday night", at(5, 19, 0), time.Time{}, []string{"Surah al-Kahf"}, 1},
+		{"friday morning", at(6, 9, 0), at(6, 13, 0), []string{"ghusl", "before Maghrib (17:34)", "salawat"}, 3},
+		{"friday after jumuah", at(6, 14, 0), at(6, 13, 0), []string{"before Maghrib", "salawat"}, 2},
+		{"friday night", at(6, 19, 0), at(6, 13, 0), []string{"salawat"}, 1},
+		{"saturday", at(7, 9, 0), time.Time{}, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maghrib := time.Date(tt.now.Year(), tt.now.Month(), tt.now.Day(), 17, 34, 0, 0, time.UTC)
+			got := FridayReminders(tt.now, maghrib, tt.jumuah)
+			if len(got) != tt.count {
+				t.Fatalf("FridayReminders() returned %d reminders, want %d: %v", len(got), tt.count, got)
+			}
+			joined := strings.Join(got, "\n")
+			for _, want := range tt.contains {
+				if !strings.Contains(joined, want) {
+					t.Errorf("FridayReminders() missing %q in %v", want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Package prayer provides prayer times calculation helpers and data
+package prayer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IqamaPrayers lists the prayers that have an iqama (congregation) time
+var IqamaPrayers = []string{"Fajr", "Dhuhr", "Asr", "Maghrib", "Isha"}
+
+// IqamaRules describes how iqama times are derived from adhan times
+type IqamaRules struct {
+	Offsets map[string]int    // Minutes after adhan, keyed by prayer name
+	Fixed   map[string]string // Fixed clock times (HH:MM), keyed by prayer name
+	Round   int               // Round computed times to the nearest N minutes (0 = off)
+}
+
+// ParseIqamaOffsets parses comma-separated offsets in event index order
+// (Fajr, Sunrise, Dhuhr, Asr, Maghrib, Isha, Midnight), e.g. "15,0,10,10,5,10,0"
+func ParseIqamaOffsets(s string) (map[string]int, error) {
+	offsets := make(map[string]int)
+	if strings.TrimSpace(s) == "" {
+		return offsets, nil
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) > MidnightIndex+1 {
+		return nil, fmt.Errorf("too many iqama offsets: got %d, want at most %d", len(parts), MidnightIndex+1)
+	}
+
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		mins, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid iqama offset %q: %w", part, err)
+		}
+		if mins < 0 || mins > 120 {
+			return nil, fmt.Errorf("iqama offset must be between 0 and 120 minutes, got %d", mins)
+		}
+		offsets[PrayerNameByIndex(i)] = mins
+	}
+
+	return offsets, nil
+}
+
+// ParseIqamaFixed parses comma-separated fixed iqama times, e.g. "Isha: 20:30, Fajr: 05:00"
+func ParseIqamaFixed(s string) (map[string]string, error) {
+	fixed := make(map[string]string)
+	if strings.TrimSpace(s) == "" {
+		return fixed, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, clock, ok := strings.Cut(entry, "=")
+		if !ok {
+			name, clock, ok = strings.Cut(entry, ":")
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid fixed iqama entry %q (expected \"Prayer: HH:MM\")", entry)
+		}
+
+		name = normalizePrayerName(strings.TrimSpace(name))
+		if !isIqamaPrayer(name) {
+			return nil, fmt.Errorf("unknown prayer in fixed iqama entry: %q", entry)
+		}
+
+		minutes, err := ParseClock(strings.TrimSpace(clock))
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixed iqama time for %s: %w", name, err)
+		}
+		fixed[name] = FormatClock(minutes)
+	}
+
+	return fixed, nil
+}
+
+// NewIqamaRules builds IqamaRules from the config string representations
+func NewIqamaRules(offsets, fixed string, round int) (*IqamaRules, error) {
+	parsedOffsets, err := ParseIqamaOffsets(offsets)
+	if err != nil {
+		return nil, err
+	}
+	parsedFixed, err := ParseIqamaFixed(fixed)
+	if err != nil {
+		return nil, err
+	}
+	if round < 0 || round > 60 {
+		return nil, fmt.Errorf("iqama rounding must be between 0 and 60 minutes, got %d", round)
+	}
+	return &IqamaRules{
+		Offsets: parsedOffsets,
+		Fixed:   parsedFixed,
+		Round:   round,
+	}, nil
+}
+
+// Compute returns the iqama time (HH:MM) for a prayer given its adhan time (HH:MM).
+// The second return value is false when the prayer has no iqama.
+func (r *IqamaRules) Compute(name, adhan string) (string, bool) {
+	if r == nil || !isIqamaPrayer(name) {
+		return "", false
+	}
+
+	if clock, ok := r.Fixed[name]; ok {
+		return clock, true
+	}
+
+	offset, ok := r.Offsets[name]
+	if !ok {
+		return "", false
+	}
+
+	minutes, err := ParseClock(adhan)
+	if err != nil {
+		return "", false
+	}
+	minutes += offset
+
+	if r.Round > 0 {
+		minutes = (minutes + r.Round/2) / r.Round * r.Round
+	}
+
+	return FormatClock(minutes % (24 * 60)), true
+}
+
+// Times computes iqama times for all prayers in the given adhan timings map
+func (r *IqamaRules) Times(adhan map[string]string) map[string]string {
+	times := make(map[string]string)
+	if r == nil {
+		return times
+	}
+	for _, name := range IqamaPrayers {
+		if t, ok := adhan[name]; ok {
+			if iqama, ok := r.Compute(name, t); ok {
+				times[name] = iqama
+			}
+		}
+	}
+	return times
+}
+
+// isIqamaPrayer reports whether a prayer has an iqama time
+func isIqamaPrayer(name string) bool {
+	for _, p := range IqamaPrayers {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePrayerName converts a prayer name to its canonical capitalization
+func normalizePrayerName(name string) string {
+	for i := FajrIndex; i <= MidnightIndex; i++ {
+		if strings.EqualFold(PrayerNameByIndex(i), name) {
+			return PrayerNameByIndex(i)
+		}
+	}
+	return name
+}
+
+// ParseClock parses a clock time (HH:MM) into minutes since midnight
+func ParseClock(s string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(s, "%d:%d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("invalid time format: %s", s)
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("time out of range: %s", s)
+	}
+	return hour*60 + minute, nil
+}
+
+// FormatClock formats minutes since midnight as a clock time (HH:MM)
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
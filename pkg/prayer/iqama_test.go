@@ -0,0 +1,103 @@
+package prayer
+
+import "testing"
+
+func TestParseIqamaOffsets(t *testing.T) {
+	offsets, err := ParseIqamaOffsets("15,0,10,10,5,10,0")
+	if err != nil {
+		t.Fatalf("ParseIqamaOffsets() error = %v", err)
+	}
+
+	want := map[string]int{"Fajr": 15, "Dhuhr": 10, "Asr": 10, "Maghrib": 5, "Isha": 10}
+	for name, mins := range want {
+		if offsets[name] != mins {
+			t.Errorf("offset for %s = %d, want %d", name, offsets[name], mins)
+		}
+	}
+
+	for _, bad := range []string{"15,abc", "-5", "15,0,10,10,5,10,0,5", "500"} {
+		if _, err := ParseIqamaOffsets(bad); err == nil {
+			t.Errorf("ParseIqamaOffsets(%q) expected error", bad)
+		}
+	}
+}
+
+func TestParseIqamaFixed(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"", map[string]string{}, false},
+		{"Isha: 20:30", map[string]string{"Isha": "20:30"}, false},
+		{"isha=20:30, Fajr: 5:00", map[string]string{"Isha": "20:30", "Fajr": "05:00"}, false},
+		{"Sunrise: 06:00", nil, true},
+		{"Isha: 25:00", nil, true},
+		{"Isha", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseIqamaFixed(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIqamaFixed(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseIqamaFixed(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("ParseIqamaFixed(%q)[%s] = %s, want %s", tt.input, k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestIqamaRulesCompute(t *testing.T) {
+	rules, err := NewIqamaRules("15,0,10,10,5,10,0", "Isha: 20:30", 5)
+	if err != nil {
+		t.Fatalf("NewIqamaRules() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		adhan  string
+		want   string
+		wantOK bool
+	}{
+		{"Fajr", "05:12", "05:25", true}, // 05:27 rounded to nearest 5
+		{"Dhuhr", "12:09", "12:20", true},
+		{"Asr", "15:12 (EET)", "15:20", true},
+		{"Maghrib", "17:34", "17:40", true},
+		{"Isha", "18:54", "20:30", true}, // fixed time wins
+		{"Sunrise", "06:44", "", false},
+		{"Midnight", "00:09", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rules.Compute(tt.name, tt.adhan)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Compute(%s, %s) = (%s, %v), want (%s, %v)", tt.name, tt.adhan, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIqamaRulesComputeWithoutRounding(t *testing.T) {
+	rules, err := NewIqamaRules("15,0,10,10,5,10,0", "", 0)
+	if err != nil {
+		t.Fatalf("NewIqamaRules() error = %v", err)
+	}
+
+	if got, _ := rules.Compute("Fajr", "05:12"); got != "05:27" {
+		t.Errorf("Compute(Fajr) = %s, want 05:27", got)
+	}
+	if got, _ := rules.Compute("Isha", "23:55"); got != "00:05" {
+		t.Errorf("Compute(Isha) across midnight = %s, want 00:05", got)
+	}
+}
@@ -0,0 +1,69 @@
+// Package prayer provides prayer times calculation helpers and data
+package prayer
+
+import "strings"
+
+// CombinedWindow describes two prayers a traveler may combine (jam')
+type CombinedWindow struct {
+	Name   string // Display name (e.g., "Dhuhr + Asr")
+	First  string // Earlier prayer of the pair
+	Second string // Later prayer of the pair
+	Taqdim string // Jam' taqdim: both prayed at the time of the first prayer (HH:MM)
+	Takhir string // Jam' ta'khir: both prayed at the time of the second prayer (HH:MM)
+	End    string // End of the combined window (HH:MM)
+}
+
+// combinablePairs lists the prayers that may be combined and the event ending their window
+var combinablePairs = []struct {
+	first, second, end string
+}{
+	{"Dhuhr", "Asr", "Maghrib"},
+	{"Maghrib", "Isha", "Midnight"},
+}
+
+// RakahCount returns the number of obligatory rak'ahs for a prayer, or 0 for non-prayer events.
+// Travelers shorten (qasr) the four-rak'ah prayers to two.
+func RakahCount(name string, traveler bool) int {
+	if strings.HasPrefix(name, JumuahName) {
+		return 2
+	}
+
+	switch name {
+	case "Fajr":
+		return 2
+	case "Maghrib":
+		return 3
+	case "Dhuhr", "Asr", "Isha":
+		if traveler {
+			return 2
+		}
+		return 4
+	}
+	return 0
+}
+
+// IsShortened reports whether a traveler shortens the prayer
+func IsShortened(name string) bool {
+	return RakahCount(name, true) < RakahCount(name, false)
+}
+
+// CombinedWindows returns the Dhuhr+Asr and Maghrib+Isha windows from adhan times (HH:MM)
+// keyed by event name. Pairs with missing times are omitted.
+func CombinedWindows(times map[string]string) []CombinedWindow {
+	var windows []CombinedWindow
+	for _, pair := range combinablePairs {
+		first, second, end := times[pair.first], times[pair.second], times[pair.end]
+		if first == "" || second == "" || end == "" {
+			continue
+		}
+		windows = append(windows, CombinedWindow{
+			Name:   pair.first + " + " + pair.second,
+			First:  pair.first,
+			Second: pair.second,
+			Taqdim: first,
+			Takhir: second,
+			End:    end,
+		})
+	}
+	return windows
+}
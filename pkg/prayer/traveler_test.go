@@ -0,0 +1,53 @@
+package prayer
+
+import "testing"
+
+func TestRakahCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		traveler bool
+		want     int
+	}{
+		{"Fajr", false, 2},
+		{"Fajr", true, 2},
+		{"Dhuhr", false, 4},
+		{"Dhuhr", true, 2},
+		{"Maghrib", true, 3},
+		{"Isha", true, 2},
+		{"Jumu'ah 2", false, 2},
+		{"Sunrise", false, 0},
+	}
+
+	for _, tt := range tests {
+		if got := RakahCount(tt.name, tt.traveler); got != tt.want {
+			t.Errorf("RakahCount(%s, %v) = %d, want %d", tt.name, tt.traveler, got, tt.want)
+		}
+	}
+}
+
+func TestCombinedWindows(t *testing.T) {
+	windows := CombinedWindows(map[string]string{
+		"Dhuhr":    "12:09",
+		"Asr":      "15:12",
+		"Maghrib":  "17:34",
+		"Isha":     "18:54",
+		"Midnight": "00:09",
+	})
+
+	if len(windows) != 2 {
+		t.Fatalf("CombinedWindows() returned %d windows, want 2", len(windows))
+	}
+
+	dhuhrAsr := windows[0]
+	if dhuhrAsr.Name != "Dhuhr + Asr" || dhuhrAsr.Taqdim != "12:09" || dhuhrAsr.Takhir != "15:12" || dhuhrAsr.End != "17:34" {
+		t.Errorf("unexpected Dhuhr + Asr window: %+v", dhuhrAsr)
+	}
+	if windows[1].End != "00:09" {
+		t.Errorf("Maghrib + Isha window end = %s, want 00:09", windows[1].End)
+	}
+
+	// Missing times omit the pair
+	if got := CombinedWindows(map[string]string{"Dhuhr": "12:09", "Asr": "15:12"}); len(got) != 0 {
+		t.Errorf("CombinedWindows() with missing Maghrib = %v, want none", got)
+	}
+}